@@ -0,0 +1,63 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+)
+
+const guildButtonInteraction = `{
+	"id": "1",
+	"type": 3,
+	"token": "t",
+	"guild_id": "10",
+	"app_permissions": "2048",
+	"member": {"user": {"id": "5"}, "roles": ["20"], "permissions": "8192"},
+	"data": {"custom_id": "admin", "component_type": 2}
+}`
+
+func TestCheckAccess(t *testing.T) {
+	interaction, err := discord.ParseInteraction(guildButtonInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itc := NewInteractionContext(interaction, nil, true)
+
+	passing := ActionOptions{
+		GuildOnly:              true,
+		RequiredRoles:          []discord.Snowflake{20},
+		RequiredPermissions:    discord.PermissionManageMessages,
+		RequiredBotPermissions: discord.PermissionSendMessages,
+	}
+	if denial := itc.CheckAccess(passing, nil); denial != nil {
+		t.Errorf("Expected access, got %+v", denial)
+	}
+
+	cases := []struct {
+		options ActionOptions
+		reason  DenialReason
+	}{
+		{ActionOptions{DMOnly: true}, DenialDMOnly},
+		{ActionOptions{OwnerOnly: true}, DenialOwnerOnly},
+		{ActionOptions{RequiredRoles: []discord.Snowflake{20, 30}}, DenialMissingRoles},
+		{ActionOptions{RequiredPermissions: discord.PermissionBanMembers}, DenialMissingPermissions},
+		{ActionOptions{RequiredBotPermissions: discord.PermissionManageRoles}, DenialMissingBotPermissions},
+	}
+
+	for _, c := range cases {
+		denial := itc.CheckAccess(c.options, nil)
+		if denial == nil || denial.Reason != c.reason {
+			t.Errorf("Expected %s, got %+v", c.reason, denial)
+		}
+	}
+
+	if denial := itc.CheckAccess(ActionOptions{OwnerOnly: true}, []discord.Snowflake{5}); denial != nil {
+		t.Errorf("Expected owners to have access, got %+v", denial)
+	}
+
+	denial := itc.CheckAccess(ActionOptions{RequiredRoles: []discord.Snowflake{20, 30}}, nil)
+	if len(denial.MissingRoles) != 1 || denial.MissingRoles[0] != 30 {
+		t.Errorf("Expected role 30 to be missing, got %v", denial.MissingRoles)
+	}
+}
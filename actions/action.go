@@ -1,5 +1,7 @@
 package actions
 
+import "github.com/JackHumphries9/dapper-go/discord"
+
 type InteractionHandler func(itc *InteractionContext)
 
 type ActionType string
@@ -11,11 +13,75 @@ const (
 	ActionTypeButton                     = "button"
 	ActionTypeSelect                     = "select"
 	ActionTypeModal                      = "modal"
+	ActionTypeWizard                     = "wizard"
+)
+
+type EntitlementScope string
+
+const (
+	EntitlementScopeUser  EntitlementScope = "user"
+	EntitlementScopeGuild EntitlementScope = "guild"
+)
+
+type RequiredSKU struct {
+	SkuId discord.Snowflake
+	Scope EntitlementScope
+}
+
+type UpsellHandler func(itc *InteractionContext, sku RequiredSKU) discord.ResponseEditData
+
+type DenialReason string
+
+const (
+	DenialGuildOnly             DenialReason = "guild_only"
+	DenialDMOnly                DenialReason = "dm_only"
+	DenialOwnerOnly             DenialReason = "owner_only"
+	DenialMissingRoles          DenialReason = "missing_roles"
+	DenialMissingPermissions    DenialReason = "missing_permissions"
+	DenialMissingBotPermissions DenialReason = "missing_bot_permissions"
 )
 
+// Denial describes why the router refused to run an action
+type Denial struct {
+	Reason DenialReason
+	// Set for DenialMissingPermissions and DenialMissingBotPermissions
+	MissingPermissions discord.Permissions
+	// Set for DenialMissingRoles
+	MissingRoles []discord.Snowflake
+}
+
+type DenialHandler func(itc *InteractionContext, denial Denial) discord.ResponseEditData
+
 type ActionOptions struct {
 	CancelDefer bool
 	Ephemeral   bool
+
+	// The router checks these against the interaction's entitlements and sends an upsell instead of running the action when one is missing
+	RequiredSKUs []RequiredSKU
+	// Builds the ephemeral upsell message, defaults to DefaultUpsell
+	Upsell UpsellHandler
+
+	// Permissions the member needs in the channel, also used as the command's default member permissions
+	RequiredPermissions discord.Permissions
+	// Roles the member needs to have, every one of them is required
+	RequiredRoles []discord.Snowflake
+	// Only the owners set with InteractionRouter.SetOwners can use the action
+	OwnerOnly bool
+	GuildOnly bool
+	DMOnly    bool
+	// Permissions the app needs in the channel, checked against the interaction's app permissions
+	RequiredBotPermissions discord.Permissions
+	// Builds the ephemeral message sent when a check fails, defaults to DefaultDenial
+	Denied DenialHandler
+
+	// Every bucket must have a use left for the action to run
+	Cooldowns []Cooldown
+	// Members with any of these roles skip cooldowns
+	CooldownBypassRoles []discord.Snowflake
+	// Defaults to a shared in-memory store
+	CooldownStore CooldownStore
+	// Builds the ephemeral message sent when a cooldown is exhausted, defaults to DefaultCooldownMessage
+	OnCooldown CooldownHandler
 }
 
 type Action interface {
@@ -2,6 +2,8 @@ package actions
 
 import (
 	"github.com/JackHumphries9/dapper-go/client"
+	"github.com/JackHumphries9/dapper-go/discord/integration_type"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_context_type"
 )
 
 type Command struct {
@@ -9,12 +11,36 @@ type Command struct {
 	Actions    []Action
 	Properties ActionOptions
 	OnInvoke   InteractionHandler
+	// Installation types the command is available for, overrides Command.IntegrationTypes when set
+	IntegrationTypes []integration_type.IntegrationType
+	// Where the command can be used, overrides Command.Contexts when set
+	Contexts []interaction_context_type.InteractionContextType
 }
 
 func (c Command) CustomID() string {
 	return c.Command.Name
 }
 
+// ApplicationCommand returns the command as it is registered with Discord
+func (c Command) ApplicationCommand() client.CreateApplicationCommand {
+	cmd := c.Command
+
+	if len(c.IntegrationTypes) > 0 {
+		cmd.IntegrationTypes = c.IntegrationTypes
+	}
+
+	if len(c.Contexts) > 0 {
+		cmd.Contexts = c.Contexts
+	}
+
+	if cmd.DefaultMemberPermissions == nil && c.Properties.RequiredPermissions != 0 {
+		permissions := c.Properties.RequiredPermissions
+		cmd.DefaultMemberPermissions = &permissions
+	}
+
+	return cmd
+}
+
 func (c Command) Options() ActionOptions {
 	return c.Properties
 }
@@ -0,0 +1,614 @@
+package actions
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/channel_type"
+	"github.com/JackHumphries9/dapper-go/discord/command_option_type"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_type"
+	"github.com/JackHumphries9/dapper-go/helpers"
+)
+
+// Command options can be declared as a struct, for example:
+//
+//	type BanOptions struct {
+//		User   *discord.User `option:"user" description:"The user to ban" required:"true"`
+//		Days   *int64        `option:"days" description:"Days of messages to delete" min:"0" max:"7"`
+//		Reason string        `description:"Why they are being banned" choices:"Spam=spam|Abuse=abuse"`
+//	}
+//
+// Supported tags are option (the name, defaults to the snake cased field name, "-" skips the field),
+// description, required, min, max (lengths for strings), choices (Name=value pairs separated by |),
+// channel_types (comma separated), autocomplete and type, which picks user, role, channel or mentionable
+// (the default) for discord.Snowflake fields that only need the id.
+//
+// Pointers to structs are treated as subcommands, and become subcommand groups when they contain subcommands themselves.
+
+type OptionBindingError struct {
+	Option  string
+	Message string
+}
+
+func (e OptionBindingError) Error() string {
+	return fmt.Sprintf("option %s: %s", e.Option, e.Message)
+}
+
+type OptionBindingErrors []OptionBindingError
+
+func (e OptionBindingErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+type optionField struct {
+	index    int
+	option   discord.ApplicationCommandOption
+	children []optionField
+}
+
+var (
+	userType       = reflect.TypeOf(discord.User{})
+	memberType     = reflect.TypeOf(discord.Member{})
+	roleType       = reflect.TypeOf(discord.Role{})
+	channelType    = reflect.TypeOf(discord.Channel{})
+	attachmentType = reflect.TypeOf(discord.Attachment{})
+	snowflakeType  = reflect.TypeOf(discord.Snowflake(0))
+)
+
+// snowflakeOptionTypes are the values of the type tag on discord.Snowflake fields
+var snowflakeOptionTypes = map[string]command_option_type.CommandOptionType{
+	"user":        command_option_type.User,
+	"role":        command_option_type.Role,
+	"channel":     command_option_type.Channel,
+	"mentionable": command_option_type.Mentionable,
+}
+
+// CommandOptionsFromStruct builds the options for a CreateApplicationCommand from the tags on a struct
+func CommandOptionsFromStruct(v any) ([]discord.ApplicationCommandOption, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("command options must be declared as a struct")
+	}
+
+	fields, err := parseOptionFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCommandOptions(fields), nil
+}
+
+// BindCommandOptions decodes the options of a command interaction into a struct declared for CommandOptionsFromStruct
+func (ic *InteractionContext) BindCommandOptions(dst any) error {
+	if ic.Interaction.Type != interaction_type.ApplicationCommand && ic.Interaction.Type != interaction_type.ApplicationCommandAutocomplete {
+		return fmt.Errorf("cannot bind command options from a non command interaction")
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("command options can only be bound to a pointer to a struct")
+	}
+
+	fields, err := parseOptionFields(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	commandData := ic.Interaction.Data.(*discord.ApplicationCommandData)
+
+	errs := make(OptionBindingErrors, 0)
+	bindOptionFields(rv.Elem(), fields, commandData.Options, commandData.Resolved, &errs)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func parseOptionFields(t reflect.Type) ([]optionField, error) {
+	fields := make([]optionField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name := structField.Tag.Get("option")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = toSnakeCase(structField.Name)
+		}
+
+		field := optionField{
+			index: i,
+			option: discord.ApplicationCommandOption{
+				Name:        name,
+				Description: structField.Tag.Get("description"),
+			},
+		}
+
+		if field.option.Description == "" {
+			return nil, fmt.Errorf("option %s must have a description", name)
+		}
+
+		if isSubcommandType(structField.Type) {
+			children, err := parseOptionFields(structField.Type.Elem())
+			if err != nil {
+				return nil, err
+			}
+
+			field.option.Type = command_option_type.SubCommand
+			for _, child := range children {
+				if child.option.Type == command_option_type.SubCommand {
+					field.option.Type = command_option_type.SubCommandGroup
+				}
+			}
+
+			field.children = children
+			fields = append(fields, field)
+			continue
+		}
+
+		optionType, ok := optionTypeFor(structField.Type)
+		if !ok {
+			return nil, fmt.Errorf("option %s has unsupported type %s", name, structField.Type)
+		}
+		field.option.Type = optionType
+
+		if rawType := structField.Tag.Get("type"); rawType != "" {
+			if optionType != command_option_type.Mentionable {
+				return nil, fmt.Errorf("option %s: type is only supported on discord.Snowflake fields", name)
+			}
+
+			optionType, ok = snowflakeOptionTypes[rawType]
+			if !ok {
+				return nil, fmt.Errorf("option %s: invalid type tag %s, must be user, role, channel or mentionable", name, rawType)
+			}
+			field.option.Type = optionType
+		}
+
+		if err := applyOptionTags(&field.option, structField.Tag); err != nil {
+			return nil, fmt.Errorf("option %s: %w", name, err)
+		}
+
+		fields = append(fields, field)
+	}
+
+	// Discord requires required options to come before optional ones
+	sort.SliceStable(fields, func(a, b int) bool {
+		return isRequired(fields[a].option) && !isRequired(fields[b].option)
+	})
+
+	return fields, nil
+}
+
+func applyOptionTags(option *discord.ApplicationCommandOption, tag reflect.StructTag) error {
+	if required := tag.Get("required"); required != "" {
+		value, err := strconv.ParseBool(required)
+		if err != nil {
+			return fmt.Errorf("invalid required tag: %w", err)
+		}
+		option.Required = helpers.Ptr(value)
+	}
+
+	if autocomplete := tag.Get("autocomplete"); autocomplete != "" {
+		value, err := strconv.ParseBool(autocomplete)
+		if err != nil {
+			return fmt.Errorf("invalid autocomplete tag: %w", err)
+		}
+		option.Autocomplete = helpers.Ptr(value)
+	}
+
+	for _, bound := range []string{"min", "max"} {
+		raw := tag.Get(bound)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s tag: %w", bound, err)
+		}
+
+		switch option.Type {
+		case command_option_type.String:
+			if bound == "min" {
+				option.MinLength = helpers.Ptr(int(value))
+			} else {
+				option.MaxLength = helpers.Ptr(int(value))
+			}
+		case command_option_type.Integer, command_option_type.Number:
+			if bound == "min" {
+				option.MinValue = helpers.Ptr(value)
+			} else {
+				option.MaxValue = helpers.Ptr(value)
+			}
+		default:
+			return fmt.Errorf("%s is only supported on string, integer and number options", bound)
+		}
+	}
+
+	if choices := tag.Get("choices"); choices != "" {
+		for _, choice := range strings.Split(choices, "|") {
+			name, rawValue, found := strings.Cut(choice, "=")
+			if !found {
+				rawValue = name
+			}
+
+			value, err := parseChoiceValue(option.Type, rawValue)
+			if err != nil {
+				return err
+			}
+
+			option.Choices = append(option.Choices, discord.ApplicationCommandOptionChoice{
+				Name:  name,
+				Value: value,
+			})
+		}
+	}
+
+	if channelTypes := tag.Get("channel_types"); channelTypes != "" {
+		if option.Type != command_option_type.Channel {
+			return fmt.Errorf("channel_types is only supported on channel options")
+		}
+
+		for _, raw := range strings.Split(channelTypes, ",") {
+			value, err := strconv.ParseUint(strings.TrimSpace(raw), 10, 8)
+			if err != nil {
+				return fmt.Errorf("invalid channel type %s: %w", raw, err)
+			}
+			option.ChannelTypes = append(option.ChannelTypes, channel_type.ChannelType(value))
+		}
+	}
+
+	return nil
+}
+
+func parseChoiceValue(optionType command_option_type.CommandOptionType, raw string) (any, error) {
+	switch optionType {
+	case command_option_type.String:
+		return raw, nil
+	case command_option_type.Integer:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer choice %s: %w", raw, err)
+		}
+		return value, nil
+	case command_option_type.Number:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number choice %s: %w", raw, err)
+		}
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("choices are only supported on string, integer and number options")
+}
+
+func toCommandOptions(fields []optionField) []discord.ApplicationCommandOption {
+	options := make([]discord.ApplicationCommandOption, 0, len(fields))
+
+	for _, field := range fields {
+		option := field.option
+		if len(field.children) > 0 {
+			option.Options = toCommandOptions(field.children)
+		}
+		options = append(options, option)
+	}
+
+	return options
+}
+
+func bindOptionFields(dst reflect.Value, fields []optionField, options []discord.ApplicationCommandDataOption, resolved *discord.ResolvedData, errs *OptionBindingErrors) {
+	byName := make(map[string]discord.ApplicationCommandDataOption, len(options))
+	for _, option := range options {
+		byName[option.Name] = option
+	}
+
+	for _, field := range fields {
+		target := dst.Field(field.index)
+		option, present := byName[field.option.Name]
+
+		if field.children != nil || isSubcommandType(target.Type()) {
+			if !present {
+				continue
+			}
+
+			subcommand := reflect.New(target.Type().Elem())
+			bindOptionFields(subcommand.Elem(), field.children, option.Options, resolved, errs)
+			target.Set(subcommand)
+			continue
+		}
+
+		if !present {
+			if isRequired(field.option) {
+				*errs = append(*errs, OptionBindingError{field.option.Name, "is required"})
+			}
+			continue
+		}
+
+		if option.Type != field.option.Type {
+			*errs = append(*errs, OptionBindingError{field.option.Name, fmt.Sprintf("expected option type %d, got %d", field.option.Type, option.Type)})
+			continue
+		}
+
+		value, err := resolveOptionValue(option, target.Type(), resolved)
+		if err != nil {
+			*errs = append(*errs, OptionBindingError{field.option.Name, err.Error()})
+			continue
+		}
+
+		if err := validateOptionValue(field.option, value); err != nil {
+			*errs = append(*errs, OptionBindingError{field.option.Name, err.Error()})
+			continue
+		}
+
+		if err := assignOptionValue(target, value); err != nil {
+			*errs = append(*errs, OptionBindingError{field.option.Name, err.Error()})
+		}
+	}
+}
+
+func resolveOptionValue(option discord.ApplicationCommandDataOption, target reflect.Type, resolved *discord.ResolvedData) (any, error) {
+	for target.Kind() == reflect.Pointer {
+		target = target.Elem()
+	}
+
+	switch option.Type {
+	case command_option_type.String:
+		value, ok := option.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, got %T", option.Value)
+		}
+		return value, nil
+	case command_option_type.Integer:
+		value, ok := option.Value.(float64)
+		if !ok || value != math.Trunc(value) {
+			return nil, fmt.Errorf("expected an integer, got %v", option.Value)
+		}
+		return int64(value), nil
+	case command_option_type.Number:
+		value, ok := option.Value.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected a number, got %T", option.Value)
+		}
+		return value, nil
+	case command_option_type.Boolean:
+		value, ok := option.Value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected a boolean, got %T", option.Value)
+		}
+		return value, nil
+	}
+
+	id, ok := option.Value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a snowflake, got %T", option.Value)
+	}
+
+	snowflake, err := discord.GetSnowflake(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if target == snowflakeType {
+		if !isResolvedAs(resolved, option.Type, snowflake) {
+			return nil, fmt.Errorf("cannot find resolved %s", id)
+		}
+		return snowflake, nil
+	}
+
+	switch target {
+	case userType:
+		if user := resolved.GetUser(snowflake); user != nil {
+			return user, nil
+		}
+	case memberType:
+		if member := resolved.GetMember(snowflake); member != nil {
+			return member, nil
+		}
+	case roleType:
+		if role := resolved.GetRole(snowflake); role != nil {
+			return role, nil
+		}
+	case channelType:
+		if channel := resolved.GetChannel(snowflake); channel != nil {
+			return channel, nil
+		}
+	case attachmentType:
+		if attachment := resolved.GetAttachment(snowflake); attachment != nil {
+			return attachment, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot find resolved %s for %s", target.Name(), id)
+}
+
+// isResolvedAs checks the id was resolved as the kind of entity the option type asks for
+func isResolvedAs(resolved *discord.ResolvedData, optionType command_option_type.CommandOptionType, id discord.Snowflake) bool {
+	switch optionType {
+	case command_option_type.User:
+		return resolved.GetUser(id) != nil
+	case command_option_type.Role:
+		return resolved.GetRole(id) != nil
+	case command_option_type.Channel:
+		return resolved.GetChannel(id) != nil
+	case command_option_type.Mentionable:
+		return resolved.GetUser(id) != nil || resolved.GetRole(id) != nil
+	}
+
+	return false
+}
+
+func validateOptionValue(option discord.ApplicationCommandOption, value any) error {
+	switch v := value.(type) {
+	case string:
+		if option.MinLength != nil && utf8.RuneCountInString(v) < *option.MinLength {
+			return fmt.Errorf("must be at least %d characters", *option.MinLength)
+		}
+		if option.MaxLength != nil && utf8.RuneCountInString(v) > *option.MaxLength {
+			return fmt.Errorf("must be at most %d characters", *option.MaxLength)
+		}
+	case int64:
+		if err := validateOptionBounds(option, float64(v)); err != nil {
+			return err
+		}
+	case float64:
+		if err := validateOptionBounds(option, v); err != nil {
+			return err
+		}
+	}
+
+	if len(option.Choices) == 0 {
+		return nil
+	}
+
+	for _, choice := range option.Choices {
+		if choice.Value == value {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%v is not one of the available choices", value)
+}
+
+func validateOptionBounds(option discord.ApplicationCommandOption, value float64) error {
+	if option.MinValue != nil && value < *option.MinValue {
+		return fmt.Errorf("must be at least %v", *option.MinValue)
+	}
+	if option.MaxValue != nil && value > *option.MaxValue {
+		return fmt.Errorf("must be at most %v", *option.MaxValue)
+	}
+
+	return nil
+}
+
+func assignOptionValue(target reflect.Value, value any) error {
+	if target.Kind() == reflect.Pointer {
+		if rv := reflect.ValueOf(value); rv.Type() == target.Type() {
+			target.Set(rv)
+			return nil
+		}
+
+		allocated := reflect.New(target.Type().Elem())
+		if err := assignOptionValue(allocated.Elem(), value); err != nil {
+			return err
+		}
+		target.Set(allocated)
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, ok := value.(int64)
+		if !ok || target.OverflowInt(v) {
+			return fmt.Errorf("cannot store %v in %s", value, target.Type())
+		}
+		target.SetInt(v)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("cannot store %v in %s", value, target.Type())
+		}
+		target.SetFloat(v)
+		return nil
+	}
+
+	if rv.Kind() == reflect.Pointer && rv.Type().Elem() == target.Type() {
+		rv = rv.Elem()
+	}
+
+	if !rv.Type().AssignableTo(target.Type()) {
+		return fmt.Errorf("cannot store %s in %s", rv.Type(), target.Type())
+	}
+
+	target.Set(rv)
+	return nil
+}
+
+func optionTypeFor(t reflect.Type) (command_option_type.CommandOptionType, bool) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case userType, memberType:
+		return command_option_type.User, true
+	case roleType:
+		return command_option_type.Role, true
+	case channelType:
+		return command_option_type.Channel, true
+	case attachmentType:
+		return command_option_type.Attachment, true
+	case snowflakeType:
+		return command_option_type.Mentionable, true
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return command_option_type.String, true
+	case reflect.Bool:
+		return command_option_type.Boolean, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return command_option_type.Integer, true
+	case reflect.Float32, reflect.Float64:
+		return command_option_type.Number, true
+	}
+
+	return 0, false
+}
+
+func isSubcommandType(t reflect.Type) bool {
+	if t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return false
+	}
+
+	_, isOption := optionTypeFor(t)
+
+	return !isOption
+}
+
+func isRequired(option discord.ApplicationCommandOption) bool {
+	return option.Required != nil && *option.Required
+}
+
+func toSnakeCase(name string) string {
+	var builder strings.Builder
+
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Keep acronyms together, so UserID becomes user_id
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				builder.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,252 @@
+package actions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/command_option_type"
+)
+
+type banOptions struct {
+	Reason string        `description:"Why they are being banned" choices:"Spam=spam|Abuse=abuse"`
+	User   *discord.User `description:"The user to ban" required:"true"`
+	Days   *int64        `description:"Days of messages to delete" min:"0" max:"7"`
+}
+
+type moderationOptions struct {
+	Ban *banOptions `description:"Ban a user"`
+}
+
+const banInteraction = `{
+	"id": "1",
+	"type": 2,
+	"token": "t",
+	"data": {
+		"id": "2",
+		"name": "mod",
+		"type": 1,
+		"options": [{"name": "ban", "type": 1, "options": [
+			{"name": "user", "type": 6, "value": "42"},
+			{"name": "days", "type": 4, "value": 3},
+			{"name": "reason", "type": 3, "value": "spam"}
+		]}],
+		"resolved": {"users": {"42": {"id": "42", "username": "spammer"}}}
+	}
+}`
+
+func TestCommandOptionsFromStruct(t *testing.T) {
+	options, err := CommandOptionsFromStruct(moderationOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(options) != 1 || options[0].Type != command_option_type.SubCommand {
+		t.Fatalf("Expected a single subcommand, got %+v", options)
+	}
+
+	ban := options[0].Options
+	if len(ban) != 3 || ban[0].Name != "user" {
+		t.Fatalf("Expected required user option to come first, got %+v", ban)
+	}
+
+	if ban[2].Type != command_option_type.Integer || *ban[2].MaxValue != 7 {
+		t.Errorf("Expected days to be an integer with a max of 7, got %+v", ban[2])
+	}
+
+	if len(ban[1].Choices) != 2 || ban[1].Choices[1].Value != "abuse" {
+		t.Errorf("Expected reason to have two choices, got %+v", ban[1].Choices)
+	}
+}
+
+func TestBindCommandOptions(t *testing.T) {
+	interaction, err := discord.ParseInteraction(banInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itc := NewInteractionContext(interaction, nil, true)
+
+	var options moderationOptions
+	if err := itc.BindCommandOptions(&options); err != nil {
+		t.Fatal(err)
+	}
+
+	if options.Ban == nil {
+		t.Fatal("Expected ban subcommand to be bound")
+	}
+
+	if options.Ban.User == nil || options.Ban.User.Username != "spammer" {
+		t.Errorf("Expected resolved user, got %+v", options.Ban.User)
+	}
+
+	if options.Ban.Days == nil || *options.Ban.Days != 3 {
+		t.Errorf("Expected days to be 3, got %v", options.Ban.Days)
+	}
+
+	if options.Ban.Reason != "spam" {
+		t.Errorf("Expected reason to be spam, got %s", options.Ban.Reason)
+	}
+}
+
+func TestBindCommandOptionsValidation(t *testing.T) {
+	interaction, err := discord.ParseInteraction(`{
+		"id": "1",
+		"type": 2,
+		"token": "t",
+		"data": {"id": "2", "name": "mod", "type": 1, "options": [{"name": "ban", "type": 1, "options": [
+			{"name": "days", "type": 4, "value": 9},
+			{"name": "reason", "type": 3, "value": "boredom"}
+		]}]}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itc := NewInteractionContext(interaction, nil, true)
+
+	var options moderationOptions
+	err = itc.BindCommandOptions(&options)
+
+	var bindingErrors OptionBindingErrors
+	if !errors.As(err, &bindingErrors) {
+		t.Fatalf("Expected binding errors, got %v", err)
+	}
+
+	if len(bindingErrors) != 3 {
+		t.Errorf("Expected 3 binding errors, got %d: %v", len(bindingErrors), bindingErrors)
+	}
+}
+
+func TestValidateOptionValue(t *testing.T) {
+	minValue, maxValue := 1.0, 10.0
+	integer := discord.ApplicationCommandOption{
+		Type:     command_option_type.Integer,
+		MinValue: &minValue,
+		MaxValue: &maxValue,
+		Choices: []discord.ApplicationCommandOptionChoice{
+			{Name: "One", Value: int64(1)},
+			{Name: "Five", Value: int64(5)},
+		},
+	}
+
+	if err := validateOptionValue(integer, int64(5)); err != nil {
+		t.Errorf("Expected 5 to be a valid choice, got %v", err)
+	}
+	if err := validateOptionValue(integer, int64(3)); err == nil {
+		t.Errorf("Expected 3 to be rejected as it isn't a choice")
+	}
+	if err := validateOptionValue(integer, int64(11)); err == nil {
+		t.Errorf("Expected 11 to be rejected by the max value")
+	}
+
+	number := discord.ApplicationCommandOption{
+		Type:    command_option_type.Number,
+		Choices: []discord.ApplicationCommandOptionChoice{{Name: "Half", Value: 0.5}},
+	}
+	if err := validateOptionValue(number, 0.25); err == nil {
+		t.Errorf("Expected 0.25 to be rejected as it isn't a choice")
+	}
+
+	maxLength := 4
+	text := discord.ApplicationCommandOption{Type: command_option_type.String, MaxLength: &maxLength}
+	if err := validateOptionValue(text, "éééé"); err != nil {
+		t.Errorf("Expected 4 non-ASCII characters to fit a max length of 4, got %v", err)
+	}
+	if err := validateOptionValue(text, "ééééé"); err == nil {
+		t.Errorf("Expected 5 characters to be rejected by a max length of 4")
+	}
+}
+
+type permitOptions struct {
+	Target  discord.Snowflake  `description:"Who to permit" required:"true"`
+	Role    discord.Snowflake  `description:"The role to grant" type:"role"`
+	Channel *discord.Snowflake `description:"Where to permit them" type:"channel" channel_types:"0"`
+	User    discord.Snowflake  `description:"Who approved it" type:"user"`
+}
+
+func TestCommandOptionsFromStructSnowflakeTypes(t *testing.T) {
+	options, err := CommandOptionsFromStruct(permitOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []command_option_type.CommandOptionType{
+		command_option_type.Mentionable,
+		command_option_type.Role,
+		command_option_type.Channel,
+		command_option_type.User,
+	}
+	for i, optionType := range expected {
+		if options[i].Type != optionType {
+			t.Errorf("option %s: expected type %d, got %d", options[i].Name, optionType, options[i].Type)
+		}
+	}
+
+	if len(options[2].ChannelTypes) != 1 {
+		t.Errorf("Expected channel types on the channel option, got %v", options[2].ChannelTypes)
+	}
+
+	if _, err := CommandOptionsFromStruct(struct {
+		Name string `description:"Name" type:"role"`
+	}{}); err == nil {
+		t.Error("Expected a type tag on a non snowflake field to fail")
+	}
+
+	if _, err := CommandOptionsFromStruct(struct {
+		Id discord.Snowflake `description:"Id" type:"emoji"`
+	}{}); err == nil {
+		t.Error("Expected an unknown type tag to fail")
+	}
+}
+
+func TestBindCommandOptionsSnowflakeTypes(t *testing.T) {
+	bind := func(options string) (permitOptions, error) {
+		interaction, err := discord.ParseInteraction(`{
+			"id": "1",
+			"type": 2,
+			"token": "t",
+			"data": {"id": "2", "name": "permit", "type": 1, "options": ` + options + `, "resolved": {
+				"users": {"5": {"id": "5"}},
+				"roles": {"10": {"id": "10"}},
+				"channels": {"20": {"id": "20"}}
+			}}
+		}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		itc := NewInteractionContext(interaction, nil, true)
+
+		var bound permitOptions
+		return bound, itc.BindCommandOptions(&bound)
+	}
+
+	bound, err := bind(`[
+		{"name": "target", "type": 9, "value": "10"},
+		{"name": "role", "type": 8, "value": "10"},
+		{"name": "channel", "type": 7, "value": "20"},
+		{"name": "user", "type": 6, "value": "5"}
+	]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bound.Target != 10 || bound.Role != 10 || bound.Channel == nil || *bound.Channel != 20 || bound.User != 5 {
+		t.Errorf("Unexpected bound options %+v", bound)
+	}
+
+	// A channel id sent for the role option, and a role id that was resolved as a user
+	_, err = bind(`[
+		{"name": "target", "type": 9, "value": "20"},
+		{"name": "role", "type": 7, "value": "20"},
+		{"name": "user", "type": 6, "value": "10"}
+	]`)
+
+	var bindingErrors OptionBindingErrors
+	if !errors.As(err, &bindingErrors) {
+		t.Fatalf("Expected binding errors, got %v", err)
+	}
+	if len(bindingErrors) != 3 {
+		t.Errorf("Expected 3 binding errors, got %d: %v", len(bindingErrors), bindingErrors)
+	}
+}
@@ -0,0 +1,81 @@
+package actions
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/client"
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/integration_type"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_context_type"
+)
+
+func TestCommandApplicationCommand(t *testing.T) {
+	command := Command{
+		Command: client.CreateApplicationCommand{
+			Name:             "ping",
+			IntegrationTypes: []integration_type.IntegrationType{integration_type.GuildInstall},
+			Contexts:         []interaction_context_type.InteractionContextType{interaction_context_type.Guild},
+		},
+	}
+
+	registered := command.ApplicationCommand()
+	if len(registered.IntegrationTypes) != 1 || len(registered.Contexts) != 1 {
+		t.Errorf("Expected the command's own integration types and contexts, got %+v", registered)
+	}
+
+	command.IntegrationTypes = []integration_type.IntegrationType{integration_type.GuildInstall, integration_type.UserInstall}
+	command.Contexts = []interaction_context_type.InteractionContextType{
+		interaction_context_type.Guild, interaction_context_type.BOT_DM, interaction_context_type.PRIVATE_CHANNEL,
+	}
+
+	marshalled, err := json.Marshal(command.ApplicationCommand())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body struct {
+		IntegrationTypes []int `json:"integration_types"`
+		Contexts         []int `json:"contexts"`
+	}
+	if err := json.Unmarshal(marshalled, &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.IntegrationTypes) != 2 || body.IntegrationTypes[1] != 1 || len(body.Contexts) != 3 {
+		t.Errorf("Expected the overrides to be sent as numbers, got %s", marshalled)
+	}
+
+	if len(command.Command.IntegrationTypes) != 1 {
+		t.Errorf("Expected the overrides not to change the command")
+	}
+}
+
+func TestInteractionContextInstallation(t *testing.T) {
+	interaction, err := discord.ParseInteraction(`{
+		"id": "1",
+		"type": 2,
+		"token": "t",
+		"context": 2,
+		"user": {"id": "5"},
+		"authorizing_integration_owners": {"1": "5"},
+		"data": {"id": "3", "name": "ping", "type": 1}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itc := NewInteractionContext(interaction, nil, true)
+
+	if !itc.IsUserInstall() || itc.IsGuildInstall() {
+		t.Errorf("Expected only a user installation")
+	}
+	if owner := itc.GetAuthorizingOwner(integration_type.UserInstall); owner == nil || *owner != 5 {
+		t.Errorf("Expected the user to own the installation, got %v", owner)
+	}
+	if owner := itc.GetAuthorizingOwner(integration_type.GuildInstall); owner != nil {
+		t.Errorf("Expected no guild installation owner, got %v", *owner)
+	}
+	if !itc.IsInContext(interaction_context_type.PRIVATE_CHANNEL) || itc.IsInContext(interaction_context_type.Guild) {
+		t.Errorf("Expected a private channel context, got %v", itc.GetContextType())
+	}
+}
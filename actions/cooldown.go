@@ -0,0 +1,201 @@
+package actions
+
+import (
+	"sync"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/helpers"
+	"github.com/JackHumphries9/dapper-go/helpers/time_type"
+)
+
+type CooldownScope string
+
+const (
+	CooldownUser    CooldownScope = "user"
+	CooldownGuild   CooldownScope = "guild"
+	CooldownChannel CooldownScope = "channel"
+	CooldownGlobal  CooldownScope = "global"
+)
+
+// Cooldown allows Uses invocations of an action per Window for each bucket in the scope
+type Cooldown struct {
+	Scope  CooldownScope
+	Uses   int
+	Window time.Duration
+}
+
+type CooldownHandler func(itc *InteractionContext, retryAt time.Time) discord.ResponseEditData
+
+// CooldownBucket is a single bucket an invocation is charged to
+type CooldownBucket struct {
+	Key    string
+	Uses   int
+	Window time.Duration
+}
+
+// CooldownStore keeps track of how many times each bucket has been used
+type CooldownStore interface {
+	// Take uses up one invocation of every bucket if all of them have one left. Otherwise nothing is used up and
+	// it returns false and when the last of the exhausted buckets resets
+	Take(buckets []CooldownBucket) (bool, time.Time)
+}
+
+type memoryCooldownBucket struct {
+	uses    int
+	resetAt time.Time
+}
+
+type MemoryCooldownStore struct {
+	mu      sync.Mutex
+	buckets map[string]memoryCooldownBucket
+	// Reset buckets are swept once the map grows to this size, so one-off users don't build up
+	sweepAt int
+}
+
+const minCooldownSweepSize = 1024
+
+var defaultCooldownStore = NewMemoryCooldownStore()
+
+func NewMemoryCooldownStore() *MemoryCooldownStore {
+	return &MemoryCooldownStore{
+		buckets: make(map[string]memoryCooldownBucket),
+		sweepAt: minCooldownSweepSize,
+	}
+}
+
+func (s *MemoryCooldownStore) Take(buckets []CooldownBucket) (bool, time.Time) {
+	return s.take(buckets, time.Now())
+}
+
+func (s *MemoryCooldownStore) take(buckets []CooldownBucket, now time.Time) (bool, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := make([]memoryCooldownBucket, len(buckets))
+	allowed := true
+	var retryAt time.Time
+
+	for i, bucket := range buckets {
+		stored, ok := s.buckets[bucket.Key]
+		if !ok || !now.Before(stored.resetAt) {
+			stored = memoryCooldownBucket{resetAt: now.Add(bucket.Window)}
+		}
+
+		if stored.uses >= bucket.Uses {
+			allowed = false
+			if stored.resetAt.After(retryAt) {
+				retryAt = stored.resetAt
+			}
+		}
+
+		current[i] = stored
+	}
+
+	if !allowed {
+		return false, retryAt
+	}
+
+	for i, bucket := range buckets {
+		current[i].uses++
+		s.buckets[bucket.Key] = current[i]
+	}
+
+	if len(s.buckets) >= s.sweepAt {
+		s.sweep(now)
+	}
+
+	return true, time.Time{}
+}
+
+func (s *MemoryCooldownStore) sweep(now time.Time) {
+	for key, bucket := range s.buckets {
+		if !now.Before(bucket.resetAt) {
+			delete(s.buckets, key)
+		}
+	}
+
+	// Grow with the number of live buckets so sweeps stay rare under constant load
+	s.sweepAt = max(minCooldownSweepSize, len(s.buckets)*2)
+}
+
+// CheckCooldowns takes a use from every one of the options' cooldown buckets for the action, returning when the
+// user can try again if any of them is exhausted, in which case none of them are charged
+func (ic *InteractionContext) CheckCooldowns(actionId string, options ActionOptions) *time.Time {
+	if len(options.Cooldowns) == 0 {
+		return nil
+	}
+
+	if ic.Interaction.Member != nil {
+		for _, roleId := range options.CooldownBypassRoles {
+			if ic.Interaction.Member.HasRole(roleId) {
+				return nil
+			}
+		}
+	}
+
+	store := options.CooldownStore
+	if store == nil {
+		store = defaultCooldownStore
+	}
+
+	buckets := make([]CooldownBucket, 0, len(options.Cooldowns))
+	for _, cooldown := range options.Cooldowns {
+		bucket := ic.cooldownBucket(cooldown.Scope)
+		if bucket == nil {
+			continue
+		}
+
+		buckets = append(buckets, CooldownBucket{
+			// The window keeps two cooldowns on the same scope, e.g. per minute and per hour, apart
+			Key:    actionId + ":" + string(cooldown.Scope) + ":" + cooldown.Window.String() + ":" + *bucket,
+			Uses:   cooldown.Uses,
+			Window: cooldown.Window,
+		})
+	}
+
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	if ok, retryAt := store.Take(buckets); !ok {
+		return &retryAt
+	}
+
+	return nil
+}
+
+func (ic *InteractionContext) cooldownBucket(scope CooldownScope) *string {
+	var id *discord.Snowflake
+
+	switch scope {
+	case CooldownUser:
+		if user := ic.GetInteractionUser(); user != nil {
+			id = &user.Id
+		}
+	case CooldownGuild:
+		id = ic.Interaction.GuildId
+		// Outside a guild the DM is the closest thing to one
+		if id == nil {
+			id = ic.Interaction.ChannelId
+		}
+	case CooldownChannel:
+		id = ic.Interaction.ChannelId
+	case CooldownGlobal:
+		return helpers.Ptr("global")
+	}
+
+	if id == nil {
+		return nil
+	}
+
+	return helpers.Ptr(id.String())
+}
+
+// DefaultCooldownMessage tells the user when they can use the action again
+func DefaultCooldownMessage(itc *InteractionContext, retryAt time.Time) discord.ResponseEditData {
+	return discord.ResponseEditData{
+		Content: helpers.Ptr("You're doing that too often, try again " +
+			helpers.ToTimestamp(retryAt, helpers.TimestampOptions{Format: time_type.RelativeTime}) + "."),
+	}
+}
@@ -0,0 +1,139 @@
+package actions
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_type"
+)
+
+func TestMemoryCooldownStore(t *testing.T) {
+	store := NewMemoryCooldownStore()
+	now := time.Now()
+	user := []CooldownBucket{{Key: "ping:user:1", Uses: 2, Window: time.Minute}}
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := store.take(user, now); !ok {
+			t.Fatalf("Expected use %d to be allowed", i+1)
+		}
+	}
+
+	ok, retryAt := store.take(user, now.Add(time.Second))
+	if ok {
+		t.Errorf("Expected the third use to be refused")
+	}
+	if !retryAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected to retry at %s, got %s", now.Add(time.Minute), retryAt)
+	}
+
+	if ok, _ := store.take([]CooldownBucket{{Key: "ping:user:2", Uses: 2, Window: time.Minute}}, now); !ok {
+		t.Errorf("Expected other buckets to be unaffected")
+	}
+
+	if ok, _ := store.take(user, now.Add(time.Minute)); !ok {
+		t.Errorf("Expected the bucket to reset after the window")
+	}
+}
+
+func TestMemoryCooldownStoreSweeps(t *testing.T) {
+	store := NewMemoryCooldownStore()
+	now := time.Now()
+
+	for i := 0; i < minCooldownSweepSize-1; i++ {
+		store.take([]CooldownBucket{{Key: strconv.Itoa(i), Uses: 1, Window: time.Second}}, now)
+	}
+
+	// Reaching the sweep size clears out the buckets that have reset
+	store.take([]CooldownBucket{{Key: "late", Uses: 1, Window: time.Second}}, now.Add(time.Minute))
+
+	if len(store.buckets) != 1 {
+		t.Errorf("Expected reset buckets to be swept, %d remain", len(store.buckets))
+	}
+}
+
+func cooldownTestContext(userId discord.Snowflake, roles ...discord.Snowflake) *InteractionContext {
+	guildId := discord.Snowflake(10)
+	channelId := discord.Snowflake(20)
+
+	itc := NewInteractionContext(&discord.Interaction{
+		Type:      interaction_type.ApplicationCommand,
+		GuildId:   &guildId,
+		ChannelId: &channelId,
+		Member:    &discord.Member{User: &discord.User{Id: userId}, Roles: roles},
+	}, nil, true)
+
+	return &itc
+}
+
+func TestCheckCooldowns(t *testing.T) {
+	options := ActionOptions{
+		Cooldowns:     []Cooldown{{Scope: CooldownUser, Uses: 1, Window: time.Minute}},
+		CooldownStore: NewMemoryCooldownStore(),
+	}
+
+	if retryAt := cooldownTestContext(1).CheckCooldowns("ping", options); retryAt != nil {
+		t.Fatalf("Expected the first use to be allowed")
+	}
+
+	retryAt := cooldownTestContext(1).CheckCooldowns("ping", options)
+	if retryAt == nil || retryAt.Before(time.Now()) {
+		t.Errorf("Expected the second use to be refused with a retry time in the future, got %v", retryAt)
+	}
+
+	if retryAt := cooldownTestContext(2).CheckCooldowns("ping", options); retryAt != nil {
+		t.Errorf("Expected other users to have their own bucket")
+	}
+
+	if retryAt := cooldownTestContext(1).CheckCooldowns("pong", options); retryAt != nil {
+		t.Errorf("Expected other actions to have their own bucket")
+	}
+}
+
+func TestCheckCooldownsBypassRoles(t *testing.T) {
+	options := ActionOptions{
+		Cooldowns:           []Cooldown{{Scope: CooldownGlobal, Uses: 1, Window: time.Minute}},
+		CooldownBypassRoles: []discord.Snowflake{99},
+		CooldownStore:       NewMemoryCooldownStore(),
+	}
+
+	for i := 0; i < 3; i++ {
+		if retryAt := cooldownTestContext(1, 99).CheckCooldowns("ping", options); retryAt != nil {
+			t.Fatalf("Expected members with a bypass role to skip the cooldown")
+		}
+	}
+
+	if retryAt := cooldownTestContext(2).CheckCooldowns("ping", options); retryAt != nil {
+		t.Errorf("Expected bypassed uses not to be charged to the bucket")
+	}
+}
+
+func TestCheckCooldownsMultipleBuckets(t *testing.T) {
+	options := ActionOptions{
+		Cooldowns: []Cooldown{
+			{Scope: CooldownUser, Uses: 2, Window: time.Minute},
+			{Scope: CooldownGlobal, Uses: 1, Window: time.Minute},
+		},
+		CooldownStore: NewMemoryCooldownStore(),
+	}
+
+	if retryAt := cooldownTestContext(1).CheckCooldowns("ping", options); retryAt != nil {
+		t.Fatalf("Expected the first use to be allowed")
+	}
+
+	// The global bucket is exhausted, so user 2 is refused and their own bucket must not be charged
+	for i := 0; i < 3; i++ {
+		if retryAt := cooldownTestContext(2).CheckCooldowns("ping", options); retryAt == nil {
+			t.Fatalf("Expected the global cap to refuse the use")
+		}
+	}
+
+	userBuckets := []CooldownBucket{{Key: "ping:user:1m0s:2", Uses: 2, Window: time.Minute}}
+	store := options.CooldownStore.(*MemoryCooldownStore)
+	for i := 0; i < 2; i++ {
+		if ok, _ := store.take(userBuckets, time.Now()); !ok {
+			t.Fatalf("Expected refused uses not to be charged to the user's bucket")
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/entitlement_type"
+)
+
+func TestIsEntitlementActive(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	consumed := true
+	notConsumed := false
+
+	cases := []struct {
+		name        string
+		entitlement discord.Entitlement
+		active      bool
+	}{
+		{"subscription in its period", discord.Entitlement{Type: entitlement_type.ApplicationSubscription, StartsAt: &past, EndsAt: &future}, true},
+		{"expired subscription", discord.Entitlement{Type: entitlement_type.ApplicationSubscription, StartsAt: &past, EndsAt: &past}, false},
+		{"subscription that hasn't started", discord.Entitlement{Type: entitlement_type.ApplicationSubscription, StartsAt: &future, EndsAt: &future}, false},
+		{"deleted entitlement", discord.Entitlement{Type: entitlement_type.Purchase, Deleted: true}, false},
+		{"consumed purchase", discord.Entitlement{Type: entitlement_type.Purchase, Consumed: &consumed}, false},
+		{"unconsumed purchase", discord.Entitlement{Type: entitlement_type.Purchase, Consumed: &notConsumed}, true},
+		// Test entitlements don't have a start or end date
+		{"test entitlement", discord.Entitlement{Type: entitlement_type.TestModeSubscription}, true},
+	}
+
+	for _, c := range cases {
+		if active := isEntitlementActive(c.entitlement); active != c.active {
+			t.Errorf("%s: expected active to be %v, got %v", c.name, c.active, active)
+		}
+	}
+}
+
+func TestIsEntitledTo(t *testing.T) {
+	interaction, err := discord.ParseInteraction(guildButtonInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userId := discord.Snowflake(5)
+	otherUserId := discord.Snowflake(6)
+	guildId := discord.Snowflake(10)
+	otherGuildId := discord.Snowflake(11)
+	past := time.Now().Add(-time.Hour)
+
+	userSKU := RequiredSKU{SkuId: 1, Scope: EntitlementScopeUser}
+	guildSKU := RequiredSKU{SkuId: 2, Scope: EntitlementScopeGuild}
+
+	cases := []struct {
+		name         string
+		entitlements []discord.Entitlement
+		user         bool
+		guild        bool
+	}{
+		{"no entitlements", nil, false, false},
+		{"user entitlement", []discord.Entitlement{{SkuID: 1, UserID: &userId, Type: entitlement_type.Purchase}}, true, false},
+		{"another user's entitlement", []discord.Entitlement{{SkuID: 1, UserID: &otherUserId, Type: entitlement_type.Purchase}}, false, false},
+		{"user entitlement for another SKU", []discord.Entitlement{{SkuID: 3, UserID: &userId, Type: entitlement_type.Purchase}}, false, false},
+		{"expired user entitlement", []discord.Entitlement{{SkuID: 1, UserID: &userId, Type: entitlement_type.ApplicationSubscription, EndsAt: &past}}, false, false},
+		{"test user entitlement", []discord.Entitlement{{SkuID: 1, UserID: &userId, Type: entitlement_type.TestModeSubscription}}, true, false},
+		{"guild entitlement", []discord.Entitlement{{SkuID: 2, GuildID: &guildId, Type: entitlement_type.ApplicationSubscription}}, false, true},
+		{"another guild's entitlement", []discord.Entitlement{{SkuID: 2, GuildID: &otherGuildId, Type: entitlement_type.ApplicationSubscription}}, false, false},
+		// A guild entitlement doesn't count as the user's own
+		{"guild entitlement for the user SKU", []discord.Entitlement{{SkuID: 1, GuildID: &guildId, Type: entitlement_type.Purchase}}, false, false},
+		{"expired and active entitlements", []discord.Entitlement{
+			{SkuID: 2, GuildID: &guildId, Type: entitlement_type.ApplicationSubscription, EndsAt: &past},
+			{SkuID: 2, GuildID: &guildId, Type: entitlement_type.ApplicationSubscription},
+		}, false, true},
+	}
+
+	for _, c := range cases {
+		interaction.Entitlements = c.entitlements
+		itc := NewInteractionContext(interaction, nil, true)
+
+		if entitled := itc.IsEntitledTo(userSKU); entitled != c.user {
+			t.Errorf("%s: expected user entitlement to be %v, got %v", c.name, c.user, entitled)
+		}
+		if entitled := itc.IsEntitledTo(guildSKU); entitled != c.guild {
+			t.Errorf("%s: expected guild entitlement to be %v, got %v", c.name, c.guild, entitled)
+		}
+	}
+
+	interaction.Entitlements = []discord.Entitlement{{SkuID: 1, UserID: &userId, Type: entitlement_type.Purchase}}
+	itc := NewInteractionContext(interaction, nil, true)
+	if missing := itc.MissingSKU([]RequiredSKU{userSKU, guildSKU}); missing == nil || *missing != guildSKU {
+		t.Errorf("Expected the guild SKU to be missing, got %v", missing)
+	}
+}
@@ -2,23 +2,32 @@ package actions
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/button_style"
 	"github.com/JackHumphries9/dapper-go/discord/command_option_type"
+	"github.com/JackHumphries9/dapper-go/discord/integration_type"
 	"github.com/JackHumphries9/dapper-go/discord/interaction_callback_type"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_context_type"
 	"github.com/JackHumphries9/dapper-go/discord/interaction_type"
 	"github.com/JackHumphries9/dapper-go/discord/message_flags"
 	"github.com/JackHumphries9/dapper-go/helpers"
 )
 
 type InteractionContext struct {
-	Interaction  *discord.Interaction
-	deferChannel chan *discord.InteractionResponse
-	hasDeferred  bool
-	messageFlags message_flags.MessageFlags
+	Interaction    *discord.Interaction
+	deferChannel   chan *discord.InteractionResponse
+	hasDeferred    bool
+	messageFlags   message_flags.MessageFlags
+	stateDelimiter string
 }
 
+// DefaultStateDelimiter separates an action's custom id from the state appended to it
+const DefaultStateDelimiter = ":"
+
 func NewInteractionContext(interaction *discord.Interaction, deferChannel chan *discord.InteractionResponse, cancelDefer bool) InteractionContext {
 	return InteractionContext{
 		Interaction:  interaction,
@@ -39,6 +48,19 @@ func (ic *InteractionContext) GetMessageFlags() message_flags.MessageFlags {
 	return ic.messageFlags
 }
 
+// SetStateDelimiter is called by the router so actions build custom ids it can route back to them
+func (ic *InteractionContext) SetStateDelimiter(delimiter string) {
+	ic.stateDelimiter = delimiter
+}
+
+func (ic *InteractionContext) StateDelimiter() string {
+	if ic.stateDelimiter == "" {
+		return DefaultStateDelimiter
+	}
+
+	return ic.stateDelimiter
+}
+
 func (ic *InteractionContext) Defer() {
 	if ic.hasDeferred {
 		fmt.Printf("Interaction already deferred")
@@ -91,14 +113,20 @@ func (ic *InteractionContext) Respond(msg discord.ResponseEditData) error {
 		responseType = interaction_callback_type.ChannelMessageWithSource
 	}
 
+	flags := ic.messageFlags
+	if msg.Flags != nil {
+		flags.AddFlag(message_flags.MessageFlags(*msg.Flags))
+	}
+
 	ic.deferChannel <- &discord.InteractionResponse{
 		Type: responseType,
 		Data: &discord.MessageCallbackData{
 			Content:         msg.Content,
-			Flags:           helpers.Ptr(int(ic.messageFlags)),
+			Flags:           helpers.Ptr(int(flags)),
 			Embeds:          msg.Embeds,
 			Components:      msg.Components,
 			AllowedMentions: msg.AllowedMentions,
+			Poll:            msg.Poll,
 		},
 	}
 
@@ -110,6 +138,10 @@ func (ic *InteractionContext) ShowModal(modal Modal) error {
 		return fmt.Errorf("Cannot show modal after deferring")
 	}
 
+	if err := modal.Modal.Verify(); err != nil {
+		return fmt.Errorf("invalid modal: %w", err)
+	}
+
 	ic.deferChannel <- &discord.InteractionResponse{
 		Type: interaction_callback_type.Modal,
 		Data: modal.Modal,
@@ -117,6 +149,18 @@ func (ic *InteractionContext) ShowModal(modal Modal) error {
 	return nil
 }
 
+// LaunchActivity launches the app's Activity, used to respond to Primary Entry Point commands with an AppHandler
+func (ic *InteractionContext) LaunchActivity() error {
+	if ic.hasDeferred {
+		return fmt.Errorf("Cannot launch activity after deferring")
+	}
+
+	ic.deferChannel <- &discord.InteractionResponse{
+		Type: interaction_callback_type.LaunchActivity,
+	}
+	return nil
+}
+
 func (ic *InteractionContext) GetIdContext() *string {
 	if ic.Interaction.Type != interaction_type.MessageComponent {
 		return nil
@@ -124,7 +168,7 @@ func (ic *InteractionContext) GetIdContext() *string {
 
 	componentData := ic.Interaction.Data.(*discord.MessageComponentData)
 
-	return helpers.GetContextFromId(componentData.CustomId)
+	return helpers.GetContextFromIdWithDelimiter(componentData.CustomId, ic.StateDelimiter())
 }
 
 // Really, all this should be in GLaDIs
@@ -136,19 +180,62 @@ func (ic *InteractionContext) GetModalTextInputValue(id string) *string {
 
 	submitData := ic.Interaction.Data.(*discord.ModalSubmitData)
 
-	for _, component := range submitData.Components {
-		textInput, ok := component.(*discord.ActionRow).Components[0].(*discord.TextInput)
+	if value, ok := submitData.TextInputValues()[id]; ok {
+		return &value
+	}
 
-		if !ok {
-			return nil
-		}
+	return nil
+}
 
-		if textInput.CustomId == id {
-			return textInput.Value
-		}
+// GetModalComponentValues returns the values submitted for a select or file upload in a modal
+func (ic *InteractionContext) GetModalComponentValues(id string) ([]string, error) {
+	if ic.Interaction.Type != interaction_type.ModalSubmit {
+		return nil, fmt.Errorf("cannot get modal values from a non modal interaction")
 	}
 
-	return nil
+	submitData := ic.Interaction.Data.(*discord.ModalSubmitData)
+
+	values, ok := submitData.ComponentValues()[id]
+	if !ok {
+		return nil, fmt.Errorf("modal has no select or file upload %s", id)
+	}
+
+	return values, nil
+}
+
+func resolveModalValues[T any](ic *InteractionContext, id string, resolve func(resolved *discord.ResolvedData, id discord.Snowflake) *T) ([]T, error) {
+	values, err := ic.GetModalComponentValues(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveValues(values, ic.Interaction.Data.(*discord.ModalSubmitData).Resolved, resolve)
+}
+
+// GetModalSelectedUsers returns the users chosen in a modal's user select
+func (ic *InteractionContext) GetModalSelectedUsers(id string) ([]discord.ResolvedUser, error) {
+	return resolveModalValues(ic, id, (*discord.ResolvedData).GetResolvedUser)
+}
+
+// GetModalSelectedRoles returns the roles chosen in a modal's role select
+func (ic *InteractionContext) GetModalSelectedRoles(id string) ([]discord.Role, error) {
+	return resolveModalValues(ic, id, (*discord.ResolvedData).GetRole)
+}
+
+// GetModalSelectedChannels returns the partial channels chosen in a modal's channel select
+func (ic *InteractionContext) GetModalSelectedChannels(id string) ([]discord.Channel, error) {
+	return resolveModalValues(ic, id, (*discord.ResolvedData).GetChannel)
+}
+
+// GetModalSelectedMentionables returns the users and roles chosen in a modal's mentionable select
+func (ic *InteractionContext) GetModalSelectedMentionables(id string) ([]discord.ResolvedMentionable, error) {
+	return resolveModalValues(ic, id, (*discord.ResolvedData).GetMentionable)
+}
+
+// GetModalAttachments returns the files uploaded through a modal's file upload
+func (ic *InteractionContext) GetModalAttachments(id string) ([]discord.Attachment, error) {
+	return resolveModalValues(ic, id, (*discord.ResolvedData).GetAttachment)
 }
 
 func (ic *InteractionContext) GetSelectValues() ([]string, error) {
@@ -167,193 +254,295 @@ func (ic *InteractionContext) GetSelectValues() ([]string, error) {
 }
 
 func GetCommandOption(itx *discord.Interaction, name string) (*discord.ApplicationCommandDataOption, error) {
-	if itx.Type != interaction_type.ApplicationCommand {
-		return nil, fmt.Errorf("cannot get command options from a non command interaction")
+	if itx.Type != interaction_type.ApplicationCommand && itx.Type != interaction_type.ApplicationCommandAutocomplete {
+		return nil, ErrWrongInteractionType{itx.Type}
 	}
 
 	commandData := itx.Data.(*discord.ApplicationCommandData)
 
-	return commandData.GetOption(name), nil
+	return commandData.FindOption(name), nil
 }
 
+// These return nil without an error when the option wasn't provided, see Option for typed errors and defaults
+
 func (ic *InteractionContext) GetStringCommandOption(name string) (*string, error) {
-	option, err := GetCommandOption(ic.Interaction, name)
+	return optionPtr[string](ic, name)
+}
 
-	if err != nil || option == nil {
-		return nil, err
-	}
+func (ic *InteractionContext) GetBoolCommandOption(name string) (*bool, error) {
+	return optionPtr[bool](ic, name)
+}
 
-	if option.Type == command_option_type.String {
-		return helpers.Ptr(option.Value.(string)), nil
-	}
+func (ic *InteractionContext) GetNumberCommandOption(name string) (*float64, error) {
+	return optionPtr[float64](ic, name)
+}
 
-	return nil, fmt.Errorf("Cannot find string option: %s", name)
+func (ic *InteractionContext) GetIntCommandOption(name string) (*int64, error) {
+	return optionPtr[int64](ic, name)
 }
 
-func (ic *InteractionContext) GetBoolCommandOption(name string) (*bool, error) {
-	option, err := GetCommandOption(ic.Interaction, name)
+// These return bare snowflakes, use the GetResolved variants below for the full objects
 
-	if err != nil || option == nil {
-		return nil, err
+func (ic *InteractionContext) GetUserCommandOption(name string) (*discord.Snowflake, error) {
+	return snowflakeOptionPtr(ic, name, command_option_type.User)
+}
+
+func (ic *InteractionContext) GetRoleCommandOption(name string) (*discord.Snowflake, error) {
+	return snowflakeOptionPtr(ic, name, command_option_type.Role)
+}
+
+func (ic *InteractionContext) GetMentionableCommandOption(name string) (*discord.Snowflake, error) {
+	option, err := snowflakeOptionPtr(ic, name, command_option_type.Mentionable)
+
+	if err == nil && option == nil {
+		return nil, ErrOptionNotFound{name}
 	}
 
-	if option.Type == command_option_type.Boolean {
-		return helpers.Ptr(option.Value.(bool)), nil
+	return option, err
+}
+
+func (ic *InteractionContext) GetChannelCommandOption(name string) (*discord.Snowflake, error) {
+	option, err := snowflakeOptionPtr(ic, name, command_option_type.Channel)
+
+	if err == nil && option == nil {
+		return nil, ErrOptionNotFound{name}
 	}
 
-	return nil, fmt.Errorf("Cannot find string option: %s", name)
+	return option, err
 }
 
-func (ic *InteractionContext) GetNumberCommandOption(name string) (*float64, error) {
+func snowflakeOptionPtr(ic *InteractionContext, name string, optionType command_option_type.CommandOptionType) (*discord.Snowflake, error) {
 	option, err := GetCommandOption(ic.Interaction, name)
 
 	if err != nil || option == nil {
 		return nil, err
 	}
 
-	if option.Type == command_option_type.Number {
-		return helpers.Ptr(option.Value.(float64)), nil
+	id, ok := optionSnowflake(option, optionType)
+
+	if !ok {
+		return nil, ErrOptionWrongType{
+			Name:     name,
+			Type:     option.Type,
+			Value:    option.Value,
+			Expected: "discord.Snowflake",
+		}
 	}
 
-	return nil, fmt.Errorf("Cannot find string option: %s", name)
+	return &id, nil
 }
 
-func (ic *InteractionContext) GetIntCommandOption(name string) (*int64, error) {
-	option, err := GetCommandOption(ic.Interaction, name)
+func (ic *InteractionContext) GetAttachmentCommandOption(name string) (*discord.Attachment, error) {
+	id, resolved, err := ic.getResolvedCommandOption(name, command_option_type.Attachment)
 
-	if err != nil || option == nil {
+	if err != nil {
 		return nil, err
 	}
 
-	if option.Type == command_option_type.Integer {
-		return helpers.Ptr(option.Value.(int64)), nil
+	if attachment := resolved.GetAttachment(id); attachment != nil {
+		return attachment, nil
 	}
 
-	return nil, fmt.Errorf("Cannot find string option: %s", name)
+	return nil, fmt.Errorf("failed to get attachment")
 }
 
-// TODO: Change these to include resolved data rather than just snowflakes
-
-func (ic *InteractionContext) GetUserCommandOption(name string) (*discord.Snowflake, error) {
+func (ic *InteractionContext) getResolvedCommandOption(name string, optionType command_option_type.CommandOptionType) (discord.Snowflake, *discord.ResolvedData, error) {
 	option, err := GetCommandOption(ic.Interaction, name)
 
-	if err != nil || option == nil {
-		return nil, err
+	if err != nil {
+		return 0, nil, err
 	}
 
-	if option.Type == command_option_type.User {
-		val, err := discord.GetSnowflake(option.Value)
+	if option == nil {
+		return 0, nil, ErrOptionNotFound{name}
+	}
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to get snowflake")
+	snowflake, ok := optionSnowflake(option, optionType)
+
+	if !ok {
+		return 0, nil, ErrOptionWrongType{
+			Name:     name,
+			Type:     option.Type,
+			Value:    option.Value,
+			Expected: "discord.Snowflake",
 		}
+	}
+
+	commandData := ic.Interaction.Data.(*discord.ApplicationCommandData)
 
-		return &val, nil
+	if commandData.Resolved == nil {
+		return 0, nil, fmt.Errorf("cannot find resolution data")
 	}
 
-	return nil, fmt.Errorf("Cannot find user option: %s", name)
+	return snowflake, commandData.Resolved, nil
 }
 
-func (ic *InteractionContext) GetRoleCommandOption(name string) (*discord.Snowflake, error) {
-	option, err := GetCommandOption(ic.Interaction, name)
+// GetResolvedUserOption returns the user for a user option, and their member when used in a guild
+func (ic *InteractionContext) GetResolvedUserOption(name string) (*discord.ResolvedUser, error) {
+	id, resolved, err := ic.getResolvedCommandOption(name, command_option_type.User)
 
-	if err != nil || option == nil {
+	if err != nil {
 		return nil, err
 	}
 
-	if option.Type == command_option_type.Role {
-		val, err := discord.GetSnowflake(option.Value)
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to get snowflake")
-		}
-
-		return &val, nil
+	if user := resolved.GetResolvedUser(id); user != nil {
+		return user, nil
 	}
 
-	return nil, fmt.Errorf("Cannot find role option: %s", name)
+	return nil, fmt.Errorf("failed to get user")
 }
 
-func (ic *InteractionContext) GetMentionableCommandOption(name string) (*discord.Snowflake, error) {
-	option, err := GetCommandOption(ic.Interaction, name)
+func (ic *InteractionContext) GetResolvedRoleOption(name string) (*discord.Role, error) {
+	id, resolved, err := ic.getResolvedCommandOption(name, command_option_type.Role)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if option == nil {
-		return nil, fmt.Errorf("couldn't get option")
+	if role := resolved.GetRole(id); role != nil {
+		return role, nil
 	}
 
-	if option.Type == command_option_type.Mentionable {
-		val, err := discord.GetSnowflake(option.Value)
+	return nil, fmt.Errorf("failed to get role")
+}
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to get snowflake")
-		}
+// GetResolvedChannelOption returns the partial channel for a channel option
+func (ic *InteractionContext) GetResolvedChannelOption(name string) (*discord.Channel, error) {
+	id, resolved, err := ic.getResolvedCommandOption(name, command_option_type.Channel)
 
-		return &val, nil
+	if err != nil {
+		return nil, err
+	}
+
+	if channel := resolved.GetChannel(id); channel != nil {
+		return channel, nil
 	}
 
-	return nil, fmt.Errorf("Cannot find mentionable option: %s", name)
+	return nil, fmt.Errorf("failed to get channel")
 }
 
-func (ic *InteractionContext) GetChannelCommandOption(name string) (*discord.Snowflake, error) {
-	option, err := GetCommandOption(ic.Interaction, name)
+func (ic *InteractionContext) GetResolvedMentionableOption(name string) (*discord.ResolvedMentionable, error) {
+	id, resolved, err := ic.getResolvedCommandOption(name, command_option_type.Mentionable)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if option == nil {
-		return nil, fmt.Errorf("couldn't get option")
+	if mentionable := resolved.GetMentionable(id); mentionable != nil {
+		return mentionable, nil
 	}
 
-	if option.Type == command_option_type.Channel {
-		val, err := discord.GetSnowflake(option.Value)
+	return nil, fmt.Errorf("failed to get mentionable")
+}
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to get snowflake")
-		}
+// GetTargetUser returns the user a user context menu command was used on
+func (ic *InteractionContext) GetTargetUser() (*discord.ResolvedUser, error) {
+	commandData, err := ic.getTargetCommandData()
+
+	if err != nil {
+		return nil, err
+	}
 
-		return &val, nil
+	if user := commandData.Resolved.GetResolvedUser(*commandData.TargetId); user != nil {
+		return user, nil
 	}
 
-	return nil, fmt.Errorf("Cannot find channel option: %s", name)
+	return nil, fmt.Errorf("failed to get target user")
 }
 
-func (ic *InteractionContext) GetAttachmentCommandOption(name string) (*discord.Attachment, error) {
-	option, err := GetCommandOption(ic.Interaction, name)
+// GetTargetMessage returns the message a message context menu command was used on
+func (ic *InteractionContext) GetTargetMessage() (*discord.Message, error) {
+	commandData, err := ic.getTargetCommandData()
 
 	if err != nil {
 		return nil, err
 	}
 
-	if option == nil {
-		return nil, fmt.Errorf("couldn't get option")
+	if message := commandData.Resolved.GetMessage(*commandData.TargetId); message != nil {
+		return message, nil
 	}
 
-	if option.Type != command_option_type.Attachment {
-		return nil, fmt.Errorf("option %s is not of type attachment", name)
+	return nil, fmt.Errorf("failed to get target message")
+}
+
+func (ic *InteractionContext) getTargetCommandData() (*discord.ApplicationCommandData, error) {
+	if ic.Interaction.Type != interaction_type.ApplicationCommand {
+		return nil, fmt.Errorf("cannot get target from a non command interaction")
 	}
 
-	id, ok := option.Value.(string)
+	commandData := ic.Interaction.Data.(*discord.ApplicationCommandData)
+
+	if commandData.TargetId == nil {
+		return nil, fmt.Errorf("command has no target")
+	}
+
+	return commandData, nil
+}
+
+func (ic *InteractionContext) getSelectData() (*discord.MessageComponentData, error) {
+	if ic.Interaction.Type != interaction_type.MessageComponent {
+		return nil, fmt.Errorf("cannot get select values from a non component interaction")
+	}
+
+	selectData, ok := ic.Interaction.Data.(*discord.MessageComponentData)
 
 	if !ok {
-		return nil, fmt.Errorf("cannot cast value to string")
+		return nil, fmt.Errorf("cannot convert to values")
 	}
 
-	commandData := ic.Interaction.Data.(*discord.ApplicationCommandData)
+	return selectData, nil
+}
 
-	if commandData.Resolved == nil || commandData.Resolved.Attachments == nil {
-		return nil, fmt.Errorf("cannot find resolution data")
+func resolveSelectValues[T any](ic *InteractionContext, resolve func(resolved *discord.ResolvedData, id discord.Snowflake) *T) ([]T, error) {
+	selectData, err := ic.getSelectData()
+
+	if err != nil {
+		return nil, err
 	}
 
-	if attachment, ok := (*commandData.Resolved.Attachments)[id]; ok {
-		return attachment, nil
+	return resolveValues(selectData.Values, selectData.Resolved, resolve)
+}
+
+func resolveValues[T any](rawValues []string, resolved *discord.ResolvedData, resolve func(resolved *discord.ResolvedData, id discord.Snowflake) *T) ([]T, error) {
+	values := make([]T, 0, len(rawValues))
+
+	for _, value := range rawValues {
+		id, err := discord.GetSnowflake(value)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snowflake")
+		}
+
+		resolvedValue := resolve(resolved, id)
+
+		if resolvedValue == nil {
+			return nil, fmt.Errorf("cannot find resolved value for %s", value)
+		}
+
+		values = append(values, *resolvedValue)
 	}
 
-	return nil, fmt.Errorf("failed to get attachment")
+	return values, nil
+}
+
+// GetSelectedUsers returns the users chosen in a user select
+func (ic *InteractionContext) GetSelectedUsers() ([]discord.ResolvedUser, error) {
+	return resolveSelectValues(ic, (*discord.ResolvedData).GetResolvedUser)
+}
+
+// GetSelectedRoles returns the roles chosen in a role select
+func (ic *InteractionContext) GetSelectedRoles() ([]discord.Role, error) {
+	return resolveSelectValues(ic, (*discord.ResolvedData).GetRole)
+}
+
+// GetSelectedChannels returns the partial channels chosen in a channel select
+func (ic *InteractionContext) GetSelectedChannels() ([]discord.Channel, error) {
+	return resolveSelectValues(ic, (*discord.ResolvedData).GetChannel)
+}
+
+// GetSelectedMentionables returns the users and roles chosen in a mentionable select
+func (ic *InteractionContext) GetSelectedMentionables() ([]discord.ResolvedMentionable, error) {
+	return resolveSelectValues(ic, (*discord.ResolvedData).GetMentionable)
 }
 
 func (ic *InteractionContext) GetInteractionUser() *discord.User {
@@ -364,6 +553,37 @@ func (ic *InteractionContext) GetInteractionUser() *discord.User {
 	}
 }
 
+// IsGuildInstall reports whether the interaction was authorized by the app's guild installation
+func (ic *InteractionContext) IsGuildInstall() bool {
+	_, ok := ic.Interaction.AuthorizingIntegrationOwners[integration_type.GuildInstall]
+	return ok
+}
+
+// IsUserInstall reports whether the interaction was authorized by the app's installation on the invoking user
+func (ic *InteractionContext) IsUserInstall() bool {
+	_, ok := ic.Interaction.AuthorizingIntegrationOwners[integration_type.UserInstall]
+	return ok
+}
+
+// GetAuthorizingOwner returns the guild id (0 outside a guild) or user id that installed the app for the given installation type
+func (ic *InteractionContext) GetAuthorizingOwner(integrationType integration_type.IntegrationType) *discord.Snowflake {
+	owner, ok := ic.Interaction.AuthorizingIntegrationOwners[integrationType]
+	if !ok {
+		return nil
+	}
+
+	return &owner
+}
+
+// GetContextType returns where the interaction was triggered, nil for interactions sent before contexts existed
+func (ic *InteractionContext) GetContextType() *interaction_context_type.InteractionContextType {
+	return ic.Interaction.Context
+}
+
+func (ic *InteractionContext) IsInContext(contextType interaction_context_type.InteractionContextType) bool {
+	return ic.Interaction.Context != nil && *ic.Interaction.Context == contextType
+}
+
 func (ic *InteractionContext) HasSubCommandOption(name string) (bool, error) {
 	option, err := GetCommandOption(ic.Interaction, name)
 
@@ -382,6 +602,115 @@ func (ic *InteractionContext) HasSubCommandOption(name string) (bool, error) {
 	return false, fmt.Errorf("Cannot find subcommand option: %s", name)
 }
 
+// Access checks here
+
+// GetMemberPermissions returns the member's permissions in the channel the interaction came from, or nil outside a guild
+func (ic *InteractionContext) GetMemberPermissions() *discord.Permissions {
+	if ic.Interaction.Member == nil {
+		return nil
+	}
+
+	return ic.Interaction.Member.Permissions
+}
+
+// MissingRoles returns the roles out of the given ones the member doesn't have
+func (ic *InteractionContext) MissingRoles(roleIds []discord.Snowflake) []discord.Snowflake {
+	missing := make([]discord.Snowflake, 0)
+
+	for _, roleId := range roleIds {
+		if ic.Interaction.Member == nil || !ic.Interaction.Member.HasRole(roleId) {
+			missing = append(missing, roleId)
+		}
+	}
+
+	return missing
+}
+
+// CheckAccess runs the context, owner, role and permission checks configured on the options, returning why the
+// interaction was denied or nil when it passed every check
+func (ic *InteractionContext) CheckAccess(options ActionOptions, owners []discord.Snowflake) *Denial {
+	inGuild := ic.Interaction.GuildId != nil
+
+	if options.GuildOnly && !inGuild {
+		return &Denial{Reason: DenialGuildOnly}
+	}
+
+	if options.DMOnly && inGuild {
+		return &Denial{Reason: DenialDMOnly}
+	}
+
+	if options.OwnerOnly {
+		user := ic.GetInteractionUser()
+		if user == nil || !slices.Contains(owners, user.Id) {
+			return &Denial{Reason: DenialOwnerOnly}
+		}
+	}
+
+	if len(options.RequiredRoles) > 0 {
+		if !inGuild {
+			return &Denial{Reason: DenialGuildOnly}
+		}
+
+		if missing := ic.MissingRoles(options.RequiredRoles); len(missing) > 0 {
+			return &Denial{Reason: DenialMissingRoles, MissingRoles: missing}
+		}
+	}
+
+	if options.RequiredPermissions != 0 {
+		permissions := ic.GetMemberPermissions()
+		if permissions == nil {
+			return &Denial{Reason: DenialGuildOnly}
+		}
+
+		if missing := permissions.Missing(options.RequiredPermissions); missing != 0 {
+			return &Denial{Reason: DenialMissingPermissions, MissingPermissions: missing}
+		}
+	}
+
+	if options.RequiredBotPermissions != 0 && inGuild {
+		var permissions discord.Permissions
+		if ic.Interaction.AppPermissions != nil {
+			permissions = *ic.Interaction.AppPermissions
+		}
+
+		if missing := permissions.Missing(options.RequiredBotPermissions); missing != 0 {
+			return &Denial{Reason: DenialMissingBotPermissions, MissingPermissions: missing}
+		}
+	}
+
+	return nil
+}
+
+// DefaultDenial tells the user why they can't use the action
+func DefaultDenial(itc *InteractionContext, denial Denial) discord.ResponseEditData {
+	var content string
+
+	switch denial.Reason {
+	case DenialGuildOnly:
+		content = "This can only be used in a server."
+	case DenialDMOnly:
+		content = "This can only be used in direct messages."
+	case DenialOwnerOnly:
+		content = "This can only be used by the bot's owners."
+	case DenialMissingRoles:
+		mentions := make([]string, len(denial.MissingRoles))
+		for i, roleId := range denial.MissingRoles {
+			mentions[i] = "<@&" + roleId.String() + ">"
+		}
+		content = "You need these roles to use this: " + strings.Join(mentions, ", ")
+	case DenialMissingPermissions:
+		content = "You need these permissions to use this: " + denial.MissingPermissions.String()
+	case DenialMissingBotPermissions:
+		content = "I need these permissions to do this: " + denial.MissingPermissions.String()
+	default:
+		content = "You can't use this."
+	}
+
+	return discord.ResponseEditData{
+		Content: helpers.Ptr(content),
+	}
+}
+
 // Entitlement checks here
 
 func (ic *InteractionContext) IsEntitledToGuildSKU(skuId discord.Snowflake) bool {
@@ -398,16 +727,89 @@ func (ic *InteractionContext) IsEntitledToGuildSKU(skuId discord.Snowflake) bool
 			continue
 		}
 
-		if e.Deleted {
+		if isEntitlementActive(e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (ic *InteractionContext) IsEntitledToUserSKU(skuId discord.Snowflake) bool {
+	user := ic.GetInteractionUser()
+
+	for _, e := range ic.Interaction.Entitlements {
+		if e.SkuID != skuId {
 			continue
 		}
 
-		if e.EndsAt != nil && time.Now().After(*e.EndsAt) {
+		if e.UserID == nil || user == nil {
 			continue
 		}
 
-		return true
+		if *e.UserID != user.Id {
+			continue
+		}
+
+		if isEntitlementActive(e) {
+			return true
+		}
 	}
 
 	return false
 }
+
+func (ic *InteractionContext) IsEntitledTo(sku RequiredSKU) bool {
+	if sku.Scope == EntitlementScopeGuild {
+		return ic.IsEntitledToGuildSKU(sku.SkuId)
+	}
+
+	return ic.IsEntitledToUserSKU(sku.SkuId)
+}
+
+// MissingSKU returns the first SKU the interaction isn't entitled to, or nil when it has all of them
+func (ic *InteractionContext) MissingSKU(skus []RequiredSKU) *RequiredSKU {
+	for _, sku := range skus {
+		if !ic.IsEntitledTo(sku) {
+			return &sku
+		}
+	}
+
+	return nil
+}
+
+func isEntitlementActive(e discord.Entitlement) bool {
+	if e.Deleted {
+		return false
+	}
+
+	if e.Consumed != nil && *e.Consumed {
+		return false
+	}
+
+	if e.StartsAt != nil && time.Now().Before(*e.StartsAt) {
+		return false
+	}
+
+	if e.EndsAt != nil && time.Now().After(*e.EndsAt) {
+		return false
+	}
+
+	return true
+}
+
+// DefaultUpsell tells the user the action is premium and shows a purchase button for the SKU
+func DefaultUpsell(itc *InteractionContext, sku RequiredSKU) discord.ResponseEditData {
+	content := "This is a premium feature, purchase it to unlock it for yourself."
+	if sku.Scope == EntitlementScopeGuild {
+		content = "This is a premium feature, purchase it to unlock it for this server."
+	}
+
+	return discord.ResponseEditData{
+		Content: helpers.Ptr(content),
+		Components: helpers.CreateActionRow(&discord.Button{
+			Style: button_style.Premium,
+			SKUId: helpers.Ptr(sku.SkuId),
+		}),
+	}
+}
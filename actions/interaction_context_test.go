@@ -0,0 +1,185 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/component_type"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_callback_type"
+	"github.com/JackHumphries9/dapper-go/discord/text_input_style"
+)
+
+func TestInteractionContext_ShowModal(t *testing.T) {
+	interaction, err := discord.ParseInteraction(guildButtonInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responses := make(chan *discord.InteractionResponse, 1)
+	itc := NewInteractionContext(interaction, responses, false)
+
+	invalid := Modal{Modal: discord.ModalCallback{CustomId: "feedback", Title: "Feedback"}}
+	if err := itc.ShowModal(invalid); err == nil {
+		t.Error("Expected a modal without components to be rejected")
+	}
+	withNil := Modal{Modal: discord.ModalCallback{CustomId: "feedback", Title: "Feedback", Components: []discord.MessageComponent{nil}}}
+	if err := itc.ShowModal(withNil); err == nil {
+		t.Error("Expected a modal with a nil component to be rejected")
+	}
+	if len(responses) != 0 {
+		t.Fatal("Expected an invalid modal not to be sent")
+	}
+
+	valid := Modal{Modal: discord.ModalCallback{
+		CustomId: "feedback",
+		Title:    "Feedback",
+		Components: []discord.MessageComponent{
+			&discord.Label{
+				LabelType: component_type.Label,
+				Label:     "Message",
+				Component: &discord.TextInput{
+					TextInputType: component_type.TextInput,
+					CustomId:      "message",
+					Style:         text_input_style.Long,
+				},
+			},
+		},
+	}}
+	if err := itc.ShowModal(valid); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	response := <-responses
+	if response.Type != interaction_callback_type.Modal {
+		t.Errorf("Expected a modal response, got %v", response.Type)
+	}
+}
+
+const userSelectInteraction = `{
+	"id": "1",
+	"type": 3,
+	"token": "t",
+	"data": {"custom_id": "pick", "component_type": 7, "values": ["1", "10"], "resolved": {
+		"users": {"1": {"id": "1", "username": "cat"}},
+		"members": {"1": {"nick": "Kitty", "roles": []}},
+		"roles": {"10": {"id": "10", "name": "Moderator"}},
+		"channels": {"20": {"id": "20", "name": "general"}}
+	}}
+}`
+
+func TestInteractionContext_GetSelected(t *testing.T) {
+	interaction, err := discord.ParseInteraction(userSelectInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itc := NewInteractionContext(interaction, nil, true)
+
+	mentionables, err := itc.GetSelectedMentionables()
+	if err != nil || len(mentionables) != 2 {
+		t.Fatalf("Expected two mentionables, got %v (%v)", mentionables, err)
+	}
+	if mentionables[0].User == nil || mentionables[0].Member == nil || mentionables[0].Member.User == nil {
+		t.Errorf("Expected the first mentionable to be a user with their member, got %+v", mentionables[0])
+	}
+	if mentionables[1].Role == nil || mentionables[1].Role.Name != "Moderator" {
+		t.Errorf("Expected the second mentionable to be the Moderator role, got %+v", mentionables[1])
+	}
+
+	// Role 10 isn't a user, so resolving every value as a user fails
+	if _, err := itc.GetSelectedUsers(); err == nil {
+		t.Errorf("Expected selected users to fail when a value isn't a user")
+	}
+	if _, err := itc.GetSelectedChannels(); err == nil {
+		t.Errorf("Expected selected channels to fail when values aren't channels")
+	}
+
+	command, err := discord.ParseInteraction(userCommandInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commandItc := NewInteractionContext(command, nil, true)
+	if _, err := commandItc.GetSelectedRoles(); err == nil {
+		t.Errorf("Expected selected values to fail on a command interaction")
+	}
+}
+
+const userCommandInteraction = `{
+	"id": "1",
+	"type": 2,
+	"token": "t",
+	"data": {"id": "2", "name": "Profile", "type": 2, "target_id": "1", "resolved": {
+		"users": {"1": {"id": "1", "username": "cat"}},
+		"members": {"1": {"nick": "Kitty", "roles": []}}
+	}}
+}`
+
+const messageCommandInteraction = `{
+	"id": "1",
+	"type": 2,
+	"token": "t",
+	"data": {"id": "2", "name": "Quote", "type": 3, "target_id": "30", "resolved": {
+		"messages": {"30": {"id": "30", "content": "Hello"}}
+	}}
+}`
+
+func TestInteractionContext_GetTarget(t *testing.T) {
+	interaction, err := discord.ParseInteraction(userCommandInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itc := NewInteractionContext(interaction, nil, true)
+
+	user, err := itc.GetTargetUser()
+	if err != nil || user.User.Username != "cat" || user.Member == nil || *user.Member.Nick != "Kitty" {
+		t.Errorf("Expected the target to be cat with their member, got %+v (%v)", user, err)
+	}
+	if _, err := itc.GetTargetMessage(); err == nil {
+		t.Errorf("Expected no target message on a user command")
+	}
+
+	interaction, err = discord.ParseInteraction(messageCommandInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itc = NewInteractionContext(interaction, nil, true)
+
+	message, err := itc.GetTargetMessage()
+	if err != nil || message.Content != "Hello" {
+		t.Errorf("Expected the target message to say Hello, got %+v (%v)", message, err)
+	}
+
+	interaction, err = discord.ParseInteraction(guildButtonInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itc = NewInteractionContext(interaction, nil, true)
+	if _, err := itc.GetTargetUser(); err == nil {
+		t.Errorf("Expected no target on a component interaction")
+	}
+}
+
+func TestInteractionContext_LaunchActivity(t *testing.T) {
+	interaction, err := discord.ParseInteraction(guildButtonInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responses := make(chan *discord.InteractionResponse, 1)
+	itc := NewInteractionContext(interaction, responses, false)
+
+	if err := itc.LaunchActivity(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if response := <-responses; response.Type != interaction_callback_type.LaunchActivity || response.Data != nil {
+		t.Errorf("Expected a bare launch activity response, got %+v", response)
+	}
+
+	deferred := NewInteractionContext(interaction, make(chan *discord.InteractionResponse, 1), true)
+	if err := deferred.LaunchActivity(); err == nil {
+		t.Error("Expected launching an activity after deferring to fail")
+	}
+}
@@ -0,0 +1,463 @@
+package actions
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/button_style"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_type"
+	"github.com/JackHumphries9/dapper-go/discord/text_input_style"
+	"github.com/JackHumphries9/dapper-go/helpers"
+)
+
+// Modals can be declared as a struct, for example:
+//
+//	type ApplicationForm struct {
+//		Name     string    `label:"Your name" required:"true" max:"32"`
+//		Age      *int      `label:"Your age"`
+//		Birthday time.Time `label:"Birthday" layout:"02/01/2006" placeholder:"DD/MM/YYYY"`
+//		About    string    `label:"About you" style:"long" regex:"^[^<>]*$"`
+//	}
+//
+// Supported tags are input (the custom id, defaults to the snake cased field name, "-" skips the field),
+// label, style (short or long), placeholder, required, min and max (lengths), regex and layout (for time.Time fields).
+// Fields can be strings, integers, floats or time.Time, pointers are left nil when the input is empty.
+
+const DefaultModalDateLayout = "2006-01-02"
+
+type ModalBindingError struct {
+	Input   string
+	Label   string
+	Message string
+}
+
+func (e ModalBindingError) Error() string {
+	return fmt.Sprintf("%s %s", e.Label, e.Message)
+}
+
+type ModalBindingErrors []ModalBindingError
+
+func (e ModalBindingErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+type modalField struct {
+	index  int
+	input  discord.TextInput
+	regex  *regexp.Regexp
+	layout string
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// ModalComponentsFromStruct builds the text inputs for a modal from the tags on a struct, pre-filled with the given values
+func ModalComponentsFromStruct(v any, values map[string]string) ([]discord.MessageComponent, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("modals must be declared as a struct")
+	}
+
+	fields, err := parseModalFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	components := make([]discord.MessageComponent, 0, len(fields))
+	for _, field := range fields {
+		input := field.input
+		if value, ok := values[input.CustomId]; ok && value != "" {
+			input.Value = helpers.Ptr(value)
+		}
+
+		components = append(components, helpers.CreateActionRow(&input)...)
+	}
+
+	return components, nil
+}
+
+// BindModal decodes and validates the text inputs of a modal submit into a struct declared for ModalComponentsFromStruct
+func (ic *InteractionContext) BindModal(dst any) error {
+	if ic.Interaction.Type != interaction_type.ModalSubmit {
+		return fmt.Errorf("cannot bind a modal from a non modal submit interaction")
+	}
+
+	submitData := ic.Interaction.Data.(*discord.ModalSubmitData)
+
+	return bindModalValues(dst, submitData.TextInputValues())
+}
+
+func bindModalValues(dst any, values map[string]string) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("modals can only be bound to a pointer to a struct")
+	}
+
+	fields, err := parseModalFields(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	errs := make(ModalBindingErrors, 0)
+
+	for _, field := range fields {
+		value := values[field.input.CustomId]
+
+		if err := validateModalValue(field, value); err != nil {
+			errs = append(errs, ModalBindingError{field.input.CustomId, field.input.Label, err.Error()})
+			continue
+		}
+
+		if err := parseModalValue(rv.Elem().Field(field.index), value, field.layout); err != nil {
+			errs = append(errs, ModalBindingError{field.input.CustomId, field.input.Label, err.Error()})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func parseModalFields(t reflect.Type) ([]modalField, error) {
+	fields := make([]modalField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		customId := structField.Tag.Get("input")
+		if customId == "-" {
+			continue
+		}
+		if customId == "" {
+			customId = toSnakeCase(structField.Name)
+		}
+
+		if !isModalFieldType(structField.Type) {
+			return nil, fmt.Errorf("input %s has unsupported type %s", customId, structField.Type)
+		}
+
+		field := modalField{
+			index: i,
+			input: discord.TextInput{
+				CustomId:    customId,
+				Style:       text_input_style.Short,
+				Label:       structField.Tag.Get("label"),
+				Placeholder: structField.Tag.Get("placeholder"),
+			},
+			layout: structField.Tag.Get("layout"),
+		}
+
+		if field.input.Label == "" {
+			field.input.Label = structField.Name
+		}
+
+		switch style := structField.Tag.Get("style"); style {
+		case "", "short":
+		case "long":
+			field.input.Style = text_input_style.Long
+		default:
+			return nil, fmt.Errorf("input %s has unknown style %s", customId, style)
+		}
+
+		if required := structField.Tag.Get("required"); required != "" {
+			value, err := strconv.ParseBool(required)
+			if err != nil {
+				return nil, fmt.Errorf("input %s has invalid required tag: %w", customId, err)
+			}
+			field.input.Required = value
+		}
+
+		for _, bound := range []string{"min", "max"} {
+			raw := structField.Tag.Get(bound)
+			if raw == "" {
+				continue
+			}
+
+			value, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, fmt.Errorf("input %s has invalid %s tag: %w", customId, bound, err)
+			}
+
+			if bound == "min" {
+				field.input.MinLength = helpers.Ptr(value)
+			} else {
+				field.input.MaxLength = helpers.Ptr(value)
+			}
+		}
+
+		if pattern := structField.Tag.Get("regex"); pattern != "" {
+			regex, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("input %s has invalid regex: %w", customId, err)
+			}
+			field.regex = regex
+		}
+
+		if field.layout == "" {
+			field.layout = DefaultModalDateLayout
+		}
+
+		fields = append(fields, field)
+	}
+
+	if len(fields) > 5 {
+		return nil, fmt.Errorf("modals can have at most 5 inputs (you have %d)", len(fields))
+	}
+
+	return fields, nil
+}
+
+func validateModalValue(field modalField, value string) error {
+	if value == "" {
+		if field.input.Required {
+			return fmt.Errorf("is required")
+		}
+		return nil
+	}
+
+	if field.input.MinLength != nil && utf8.RuneCountInString(value) < *field.input.MinLength {
+		return fmt.Errorf("must be at least %d characters", *field.input.MinLength)
+	}
+
+	if field.input.MaxLength != nil && utf8.RuneCountInString(value) > *field.input.MaxLength {
+		return fmt.Errorf("must be at most %d characters", *field.input.MaxLength)
+	}
+
+	if field.regex != nil && !field.regex.MatchString(value) {
+		return fmt.Errorf("is not in the expected format")
+	}
+
+	return nil
+}
+
+func parseModalValue(target reflect.Value, value string, layout string) error {
+	if target.Kind() == reflect.Pointer {
+		if value == "" {
+			target.Set(reflect.Zero(target.Type()))
+			return nil
+		}
+
+		allocated := reflect.New(target.Type().Elem())
+		if err := parseModalValue(allocated.Elem(), value, layout); err != nil {
+			return err
+		}
+		target.Set(allocated)
+		return nil
+	}
+
+	if value == "" {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+
+	if target.Type() == timeType {
+		parsed, err := time.Parse(layout, strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("must be a date like %s", layout)
+		}
+		target.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || target.OverflowInt(parsed) {
+			return fmt.Errorf("must be a whole number")
+		}
+		target.SetInt(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		target.SetFloat(parsed)
+	}
+
+	return nil
+}
+
+func isModalFieldType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// ModalForm is a modal declared as a struct. When a submission fails validation the user is sent an ephemeral
+// message listing the problems, with a button that re-opens the modal with their previous answers filled in.
+type ModalForm[T any] struct {
+	// Must be unique among registered actions and cannot contain the state delimiter
+	CustomId   string
+	Title      string
+	Properties ActionOptions
+	// Defaults to an in-memory store
+	Store StateStore
+	// Defaults to "Fix it"
+	FixLabel string
+
+	OnSubmit func(itc *InteractionContext, result T)
+}
+
+func (f ModalForm[T]) CustomID() string {
+	return f.CustomId
+}
+
+func (f ModalForm[T]) Options() ActionOptions {
+	return f.Properties
+}
+
+func (f ModalForm[T]) Type() ActionType {
+	return ActionTypeModal
+}
+
+func (f ModalForm[T]) AssociatedActions() []Action {
+	return []Action{}
+}
+
+// Modal builds the modal, pre-filled with the given values keyed by input custom id
+func (f ModalForm[T]) Modal(values map[string]string) (discord.ModalCallback, error) {
+	var form T
+
+	components, err := ModalComponentsFromStruct(form, values)
+	if err != nil {
+		return discord.ModalCallback{}, err
+	}
+
+	return discord.ModalCallback{
+		CustomId:   f.CustomId,
+		Title:      f.Title,
+		Components: components,
+	}, nil
+}
+
+func (f ModalForm[T]) Show(itc *InteractionContext) error {
+	modal, err := f.Modal(nil)
+	if err != nil {
+		return err
+	}
+
+	return itc.ShowModal(Modal{Modal: modal})
+}
+
+func (f ModalForm[T]) Handler(itc *InteractionContext) {
+	var err error
+
+	switch itc.Interaction.Type {
+	case interaction_type.ModalSubmit:
+		err = f.handleSubmit(itc)
+	case interaction_type.MessageComponent:
+		err = f.handleFix(itc)
+	default:
+		err = fmt.Errorf("modal forms can only handle modal submits and components")
+	}
+
+	if err != nil {
+		fmt.Printf("modal form %s failed to handle interaction: %v\n", f.CustomId, err)
+	}
+}
+
+func (f ModalForm[T]) handleSubmit(itc *InteractionContext) error {
+	values := itc.Interaction.Data.(*discord.ModalSubmitData).TextInputValues()
+
+	var result T
+	err := bindModalValues(&result, values)
+
+	bindingErrors, ok := err.(ModalBindingErrors)
+	if err != nil && !ok {
+		return err
+	}
+
+	if len(bindingErrors) == 0 {
+		if f.OnSubmit != nil {
+			f.OnSubmit(itc, result)
+		}
+		return nil
+	}
+
+	key := newStateKey()
+	f.store().Set(f.stateKey(key), values)
+
+	content := "Please fix the following and try again:"
+	for _, bindingError := range bindingErrors {
+		content += "\n- " + bindingError.Error()
+	}
+
+	fixLabel := f.FixLabel
+	if fixLabel == "" {
+		fixLabel = "Fix it"
+	}
+
+	itc.SetEphemeral(true)
+
+	return itc.Respond(discord.ResponseEditData{
+		Content: helpers.Ptr(content),
+		Components: helpers.CreateActionRow(&discord.Button{
+			Style:    button_style.Primary,
+			Label:    helpers.Ptr(fixLabel),
+			CustomId: helpers.Ptr(f.CustomId + itc.StateDelimiter() + key),
+		}),
+	})
+}
+
+func (f ModalForm[T]) handleFix(itc *InteractionContext) error {
+	key := itc.GetIdContext()
+	if key == nil {
+		return f.Show(itc)
+	}
+
+	var values map[string]string
+	if stored, ok := f.store().Get(f.stateKey(*key)); ok {
+		values, _ = stored.(map[string]string)
+	}
+
+	modal, err := f.Modal(values)
+	if err != nil {
+		return err
+	}
+
+	return itc.ShowModal(Modal{Modal: modal})
+}
+
+func (f ModalForm[T]) store() StateStore {
+	if f.Store == nil {
+		return defaultStateStore
+	}
+
+	return f.Store
+}
+
+func (f ModalForm[T]) stateKey(key string) string {
+	return "modal:" + f.CustomId + ":" + key
+}
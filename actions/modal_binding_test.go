@@ -0,0 +1,151 @@
+package actions
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_type"
+)
+
+type modalBindingTestForm struct {
+	Name     string    `label:"Your name" required:"true" min:"2" max:"4"`
+	Age      *int      `label:"Your age"`
+	Birthday time.Time `label:"Birthday" layout:"02/01/2006"`
+	About    string    `label:"About you" style:"long" regex:"^[^<>]*$"`
+}
+
+func TestBindModalValues(t *testing.T) {
+	var form modalBindingTestForm
+	err := bindModalValues(&form, map[string]string{
+		"name":     "Zoë",
+		"age":      "30",
+		"birthday": "26/04/2015",
+		"about":    "Hello",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if form.Name != "Zoë" || form.Age == nil || *form.Age != 30 || form.About != "Hello" {
+		t.Errorf("Expected the values to be bound, got %+v", form)
+	}
+	if !form.Birthday.Equal(time.Date(2015, 4, 26, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the birthday to be parsed with the layout, got %s", form.Birthday)
+	}
+}
+
+func TestBindModalValuesCountsCharacters(t *testing.T) {
+	var form modalBindingTestForm
+
+	// 4 characters but 8 bytes, so it must pass the max of 4
+	if err := bindModalValues(&form, map[string]string{"name": "éééé"}); err != nil {
+		t.Errorf("Expected 4 non-ASCII characters to fit a max of 4, got %v", err)
+	}
+
+	err := bindModalValues(&form, map[string]string{"name": "é"})
+	var bindingErrors ModalBindingErrors
+	if !errors.As(err, &bindingErrors) || len(bindingErrors) != 1 || bindingErrors[0].Input != "name" {
+		t.Errorf("Expected a single character to fail the min of 2, got %v", err)
+	}
+}
+
+func TestBindModalValuesCollectsErrors(t *testing.T) {
+	var form modalBindingTestForm
+	err := bindModalValues(&form, map[string]string{
+		"age":      "thirty",
+		"birthday": "2015-04-26",
+		"about":    "<script>",
+	})
+
+	var bindingErrors ModalBindingErrors
+	if !errors.As(err, &bindingErrors) {
+		t.Fatalf("Expected ModalBindingErrors, got %v", err)
+	}
+
+	inputs := make([]string, 0, len(bindingErrors))
+	for _, bindingError := range bindingErrors {
+		inputs = append(inputs, bindingError.Input)
+	}
+	if strings.Join(inputs, ",") != "name,age,birthday,about" {
+		t.Errorf("Expected every invalid input to be reported, got %v", inputs)
+	}
+}
+
+func TestModalComponentsFromStruct(t *testing.T) {
+	components, err := ModalComponentsFromStruct(modalBindingTestForm{}, map[string]string{"name": "Zoë"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(components) != 4 {
+		t.Fatalf("Expected 4 inputs, got %d", len(components))
+	}
+
+	name := components[0].(*discord.ActionRow).Components[0].(*discord.TextInput)
+	if name.CustomId != "name" || name.Label != "Your name" || !name.Required || name.Value == nil || *name.Value != "Zoë" {
+		t.Errorf("Expected a pre-filled, required name input, got %+v", name)
+	}
+
+	if _, err := ModalComponentsFromStruct("not a struct", nil); err == nil {
+		t.Errorf("Expected an error for a non struct")
+	}
+}
+
+const invalidModalFormSubmit = `{
+	"id": "1",
+	"type": 5,
+	"token": "t",
+	"data": {"custom_id": "apply", "components": [
+		{"type": 1, "components": [{"type": 4, "custom_id": "name", "value": "a"}]},
+		{"type": 1, "components": [{"type": 4, "custom_id": "about", "value": "fine"}]}
+	]}
+}`
+
+func TestModalFormFix(t *testing.T) {
+	form := ModalForm[modalBindingTestForm]{
+		CustomId: "apply",
+		Title:    "Apply",
+		Store:    NewMemoryStateStore(0),
+		OnSubmit: func(itc *InteractionContext, result modalBindingTestForm) {
+			t.Errorf("Expected invalid input not to be submitted")
+		},
+	}
+
+	interaction, err := discord.ParseInteraction(invalidModalFormSubmit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	responses := make(chan *discord.InteractionResponse, 1)
+	itc := NewInteractionContext(interaction, responses, false)
+	itc.SetStateDelimiter("|")
+
+	form.Handler(&itc)
+
+	data := (<-responses).Data.(*discord.MessageCallbackData)
+	if !strings.Contains(*data.Content, "Your name must be at least 2 characters") {
+		t.Errorf("Expected the name error to be listed, got %q", *data.Content)
+	}
+
+	fixId := *data.Components[0].(*discord.ActionRow).Components[0].(*discord.Button).CustomId
+	if !strings.HasPrefix(fixId, "apply|") {
+		t.Fatalf("Expected the fix button to use the state delimiter, got %s", fixId)
+	}
+
+	fixItc := NewInteractionContext(&discord.Interaction{
+		Type: interaction_type.MessageComponent,
+		Data: &discord.MessageComponentData{CustomId: fixId},
+	}, responses, false)
+	fixItc.SetStateDelimiter("|")
+
+	form.Handler(&fixItc)
+
+	modal := (<-responses).Data.(discord.ModalCallback)
+	about := modal.Components[3].(*discord.ActionRow).Components[0].(*discord.TextInput)
+	if about.Value == nil || *about.Value != "fine" {
+		t.Errorf("Expected the modal to be re-opened with the previous answers, got %v", about.Value)
+	}
+}
@@ -0,0 +1,199 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/command_option_type"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_type"
+)
+
+type ErrOptionNotFound struct {
+	Name string
+}
+
+func (e ErrOptionNotFound) Error() string {
+	return fmt.Sprintf("option %s was not provided", e.Name)
+}
+
+type ErrOptionWrongType struct {
+	Name     string
+	Type     command_option_type.CommandOptionType
+	Value    any
+	Expected string
+}
+
+func (e ErrOptionWrongType) Error() string {
+	return fmt.Sprintf("option %s (type %d, value %v) cannot be read as %s", e.Name, e.Type, e.Value, e.Expected)
+}
+
+type ErrWrongInteractionType struct {
+	Type interaction_type.InteractionType
+}
+
+func (e ErrWrongInteractionType) Error() string {
+	return fmt.Sprintf("cannot get command options from a non command interaction (type %d)", e.Type)
+}
+
+// Option reads a command option as T, looking inside the invoked subcommand group and subcommand.
+//
+// T can be string, bool, int, int64, float64, discord.Snowflake (for any user, role, channel, mentionable
+// or attachment option), discord.ResolvedUser, discord.User, discord.Member, discord.Role, discord.Channel,
+// discord.Attachment or discord.ResolvedMentionable.
+func Option[T any](itc *InteractionContext, name string) (T, error) {
+	var result T
+
+	if itc.Interaction.Type != interaction_type.ApplicationCommand && itc.Interaction.Type != interaction_type.ApplicationCommandAutocomplete {
+		return result, ErrWrongInteractionType{itc.Interaction.Type}
+	}
+
+	commandData := itc.Interaction.Data.(*discord.ApplicationCommandData)
+
+	option := commandData.FindOption(name)
+	if option == nil {
+		return result, ErrOptionNotFound{name}
+	}
+
+	value, ok := coerceOption[T](option, commandData.Resolved)
+	if !ok {
+		return result, ErrOptionWrongType{
+			Name:     name,
+			Type:     option.Type,
+			Value:    option.Value,
+			Expected: fmt.Sprintf("%T", result),
+		}
+	}
+
+	return value, nil
+}
+
+// OptionOr reads an optional command option, returning fallback when it wasn't provided
+func OptionOr[T any](itc *InteractionContext, name string, fallback T) (T, error) {
+	value, err := Option[T](itc, name)
+
+	var notFound ErrOptionNotFound
+	if errors.As(err, &notFound) {
+		return fallback, nil
+	}
+
+	return value, err
+}
+
+// optionPtr keeps the behaviour of the Get*CommandOption methods, returning nil without an error when the option is missing
+func optionPtr[T any](itc *InteractionContext, name string) (*T, error) {
+	value, err := Option[T](itc, name)
+
+	var notFound ErrOptionNotFound
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+func coerceOption[T any](option *discord.ApplicationCommandDataOption, resolved *discord.ResolvedData) (T, bool) {
+	var result T
+	var value any
+	var ok bool
+
+	switch any(result).(type) {
+	case string:
+		value, ok = option.Value.(string)
+		ok = ok && option.Type == command_option_type.String
+	case bool:
+		value, ok = option.Value.(bool)
+		ok = ok && option.Type == command_option_type.Boolean
+	case int, int64:
+		var number int64
+		number, ok = optionInteger(option)
+		if _, isInt := any(result).(int); isInt {
+			value = int(number)
+		} else {
+			value = number
+		}
+	case float64:
+		value, ok = option.Value.(float64)
+		ok = ok && (option.Type == command_option_type.Number || option.Type == command_option_type.Integer)
+	case discord.Snowflake:
+		value, ok = optionSnowflake(option, command_option_type.User, command_option_type.Role, command_option_type.Channel, command_option_type.Mentionable, command_option_type.Attachment)
+	case discord.ResolvedUser:
+		value, ok = resolveOption(option, resolved.GetResolvedUser, command_option_type.User, command_option_type.Mentionable)
+	case discord.User:
+		value, ok = resolveOption(option, resolved.GetUser, command_option_type.User, command_option_type.Mentionable)
+	case discord.Member:
+		value, ok = resolveOption(option, resolved.GetMember, command_option_type.User, command_option_type.Mentionable)
+	case discord.Role:
+		value, ok = resolveOption(option, resolved.GetRole, command_option_type.Role, command_option_type.Mentionable)
+	case discord.Channel:
+		value, ok = resolveOption(option, resolved.GetChannel, command_option_type.Channel)
+	case discord.Attachment:
+		value, ok = resolveOption(option, resolved.GetAttachment, command_option_type.Attachment)
+	case discord.ResolvedMentionable:
+		value, ok = resolveOption(option, resolved.GetMentionable, command_option_type.Mentionable, command_option_type.User, command_option_type.Role)
+	}
+
+	if !ok {
+		return result, false
+	}
+
+	result, ok = value.(T)
+
+	return result, ok
+}
+
+// Integers arrive as float64 when decoded from JSON
+func optionInteger(option *discord.ApplicationCommandDataOption) (int64, bool) {
+	if option.Type != command_option_type.Integer {
+		return 0, false
+	}
+
+	switch value := option.Value.(type) {
+	case float64:
+		if value != math.Trunc(value) {
+			return 0, false
+		}
+		return int64(value), true
+	case int64:
+		return value, true
+	case int:
+		return int64(value), true
+	}
+
+	return 0, false
+}
+
+func optionSnowflake(option *discord.ApplicationCommandDataOption, types ...command_option_type.CommandOptionType) (discord.Snowflake, bool) {
+	for _, optionType := range types {
+		if option.Type != optionType {
+			continue
+		}
+
+		id, err := discord.GetSnowflake(option.Value)
+
+		return id, err == nil
+	}
+
+	return 0, false
+}
+
+func resolveOption[V any](option *discord.ApplicationCommandDataOption, resolve func(id discord.Snowflake) *V, types ...command_option_type.CommandOptionType) (V, bool) {
+	var result V
+
+	id, ok := optionSnowflake(option, types...)
+	if !ok {
+		return result, false
+	}
+
+	resolvedValue := resolve(id)
+	if resolvedValue == nil {
+		return result, false
+	}
+
+	return *resolvedValue, true
+}
@@ -0,0 +1,59 @@
+package actions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+)
+
+const nestedOptionInteraction = `{
+	"id": "1",
+	"type": 2,
+	"token": "t",
+	"data": {"id": "2", "name": "settings", "type": 1, "options": [{"name": "limits", "type": 2, "options": [
+		{"name": "set", "type": 1, "options": [
+			{"name": "amount", "type": 4, "value": 25},
+			{"name": "channel", "type": 7, "value": "99"}
+		]}
+	]}]}
+}`
+
+func TestOption(t *testing.T) {
+	interaction, err := discord.ParseInteraction(nestedOptionInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itc := NewInteractionContext(interaction, nil, true)
+
+	amount, err := Option[int64](&itc, "amount")
+	if err != nil || amount != 25 {
+		t.Errorf("Expected amount to be 25, got %d (%v)", amount, err)
+	}
+
+	channel, err := Option[discord.Snowflake](&itc, "channel")
+	if err != nil || channel != 99 {
+		t.Errorf("Expected channel to be 99, got %d (%v)", channel, err)
+	}
+
+	legacy, err := itc.GetIntCommandOption("amount")
+	if err != nil || legacy == nil || *legacy != 25 {
+		t.Errorf("Expected GetIntCommandOption to return 25, got %v (%v)", legacy, err)
+	}
+
+	reason, err := OptionOr(&itc, "reason", "none")
+	if err != nil || reason != "none" {
+		t.Errorf("Expected default reason, got %s (%v)", reason, err)
+	}
+
+	_, err = Option[string](&itc, "amount")
+	if !errors.As(err, &ErrOptionWrongType{}) {
+		t.Errorf("Expected ErrOptionWrongType, got %v", err)
+	}
+
+	_, err = Option[string](&itc, "missing")
+	if !errors.As(err, &ErrOptionNotFound{}) {
+		t.Errorf("Expected ErrOptionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package actions
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// StateStore persists state between interactions, keyed by an id embedded in a custom id
+type StateStore interface {
+	Get(key string) (any, bool)
+	Set(key string, value any)
+	Delete(key string)
+}
+
+type memoryStateEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+type MemoryStateStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]memoryStateEntry
+}
+
+// Interaction tokens are only valid for 15 minutes, so there is little point keeping state for longer
+const DefaultStateTTL = 15 * time.Minute
+
+var defaultStateStore = NewMemoryStateStore(DefaultStateTTL)
+
+// NewMemoryStateStore creates an in-memory store, a ttl of 0 keeps entries until they are deleted
+func NewMemoryStateStore(ttl time.Duration) *MemoryStateStore {
+	return &MemoryStateStore{
+		ttl:     ttl,
+		entries: make(map[string]memoryStateEntry),
+	}
+}
+
+func (s *MemoryStateStore) Get(key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if s.isExpired(entry, time.Now()) {
+		delete(s.entries, key)
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+func (s *MemoryStateStore) Set(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	// Sweep expired entries so abandoned state doesn't build up
+	for k, entry := range s.entries {
+		if s.isExpired(entry, now) {
+			delete(s.entries, k)
+		}
+	}
+
+	s.entries[key] = memoryStateEntry{
+		value:     value,
+		expiresAt: now.Add(s.ttl),
+	}
+}
+
+func (s *MemoryStateStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
+
+func (s *MemoryStateStore) isExpired(entry memoryStateEntry, now time.Time) bool {
+	return s.ttl > 0 && now.After(entry.expiresAt)
+}
+
+func newStateKey() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,445 @@
+package actions
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/button_style"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_type"
+	"github.com/JackHumphries9/dapper-go/helpers"
+)
+
+type WizardStepType string
+
+const (
+	WizardStepModal   WizardStepType = "modal"
+	WizardStepSelect  WizardStepType = "select"
+	WizardStepConfirm WizardStepType = "confirm"
+)
+
+type WizardSubmitHandler[T any] func(itc *InteractionContext, result *T) error
+
+type WizardStep[T any] struct {
+	Type  WizardStepType
+	Title string
+
+	// Only used by modal steps, the custom id is managed by the wizard
+	Modal *discord.ModalCallback
+	// Only used by select steps, the custom id is managed by the wizard
+	Select *discord.SelectMenu
+
+	// Content shown with select and confirm steps
+	Prompt func(result *T) string
+
+	// Copies the submitted values into result. Returning an error rejects the input and shows the step again
+	Submit WizardSubmitHandler[T]
+}
+
+func ModalStep[T any](title string, modal discord.ModalCallback, submit WizardSubmitHandler[T]) WizardStep[T] {
+	return WizardStep[T]{
+		Type:   WizardStepModal,
+		Title:  title,
+		Modal:  &modal,
+		Submit: submit,
+	}
+}
+
+func SelectStep[T any](title string, menu discord.SelectMenu, prompt func(result *T) string, submit WizardSubmitHandler[T]) WizardStep[T] {
+	return WizardStep[T]{
+		Type:   WizardStepSelect,
+		Title:  title,
+		Select: &menu,
+		Prompt: prompt,
+		Submit: submit,
+	}
+}
+
+func ConfirmStep[T any](title string, prompt func(result *T) string) WizardStep[T] {
+	return WizardStep[T]{
+		Type:   WizardStepConfirm,
+		Title:  title,
+		Prompt: prompt,
+	}
+}
+
+type WizardLabels struct {
+	Continue  string
+	Back      string
+	Cancel    string
+	Confirm   string
+	Cancelled string
+	Expired   string
+}
+
+var defaultWizardLabels = WizardLabels{
+	Continue:  "Continue",
+	Back:      "Back",
+	Cancel:    "Cancel",
+	Confirm:   "Confirm",
+	Cancelled: "Cancelled.",
+	Expired:   "This form has expired, please start again.",
+}
+
+type Wizard[T any] struct {
+	// Must be unique among registered actions and cannot contain the state delimiter
+	Id         string
+	Steps      []WizardStep[T]
+	Properties ActionOptions
+	// Defaults to an in-memory store
+	Store  StateStore
+	Labels WizardLabels
+
+	OnComplete func(itc *InteractionContext, result T)
+	// Defaults to responding with Labels.Cancelled
+	OnCancel InteractionHandler
+}
+
+// wizardSession is the state kept in the StateStore, it holds only plain data so any store can keep it
+type wizardSession[T any] struct {
+	Step    int
+	Result  T
+	History []T
+}
+
+// sessionLocks serialises interactions on the same session key so quick clicks are applied one at a time.
+// Locks live here rather than in the stored session so they work whatever the StateStore does with its values
+type sessionLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sessionLock
+}
+
+type sessionLock struct {
+	mu      sync.Mutex
+	holders int
+}
+
+var wizardSessionLocks = &sessionLocks{locks: make(map[string]*sessionLock)}
+
+// lock blocks until the key is free and returns the function that releases it
+func (l *sessionLocks) lock(key string) func() {
+	l.mu.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &sessionLock{}
+		l.locks[key] = lock
+	}
+	lock.holders++
+	l.mu.Unlock()
+
+	lock.mu.Lock()
+
+	return func() {
+		lock.mu.Unlock()
+
+		l.mu.Lock()
+		lock.holders--
+		// Nobody is waiting, so drop the lock rather than keeping one per session forever
+		if lock.holders == 0 {
+			delete(l.locks, key)
+		}
+		l.mu.Unlock()
+	}
+}
+
+const (
+	wizardOpSubmit  = "submit"
+	wizardOpOpen    = "open"
+	wizardOpBack    = "back"
+	wizardOpCancel  = "cancel"
+	wizardOpConfirm = "confirm"
+)
+
+func (w Wizard[T]) CustomID() string {
+	return w.Id
+}
+
+func (w Wizard[T]) Options() ActionOptions {
+	return w.Properties
+}
+
+func (w Wizard[T]) Type() ActionType {
+	return ActionTypeWizard
+}
+
+func (w Wizard[T]) AssociatedActions() []Action {
+	return []Action{}
+}
+
+// Start begins a new session for the user, showing the first step
+func (w Wizard[T]) Start(itc *InteractionContext) error {
+	var initial T
+
+	return w.StartWith(itc, initial)
+}
+
+// StartWith begins a new session with a pre-populated result
+func (w Wizard[T]) StartWith(itc *InteractionContext, initial T) error {
+	if len(w.Steps) == 0 {
+		return fmt.Errorf("wizard %s has no steps", w.Id)
+	}
+
+	sessionId := newStateKey()
+	session := &wizardSession[T]{
+		Result: initial,
+	}
+
+	w.saveSession(sessionId, session)
+
+	return w.showStep(itc, sessionId, session, "")
+}
+
+func (w Wizard[T]) Handler(itc *InteractionContext) {
+	sessionId, op, step, err := w.parseCustomId(itc)
+	if err != nil {
+		_ = itc.Respond(discord.ResponseEditData{Content: helpers.Ptr(w.label(w.Labels.Expired, defaultWizardLabels.Expired))})
+		return
+	}
+
+	unlock := wizardSessionLocks.lock(w.sessionKey(sessionId))
+	defer unlock()
+
+	// Loaded under the lock, so a click that waited on one completing or cancelling the session finds it gone
+	session, ok := w.loadSession(sessionId)
+	if !ok {
+		_ = itc.Respond(discord.ResponseEditData{Content: helpers.Ptr(w.label(w.Labels.Expired, defaultWizardLabels.Expired))})
+		return
+	}
+
+	// Components from an earlier step were used, so put the user back where they are
+	if step != session.Step && op != wizardOpCancel {
+		_ = w.showStep(itc, sessionId, session, "")
+		return
+	}
+
+	switch op {
+	case wizardOpOpen:
+		var modal discord.ModalCallback
+		if modal, err = w.stepModal(itc, sessionId, session.Step); err == nil {
+			err = itc.ShowModal(Modal{Modal: modal})
+		}
+	case wizardOpBack:
+		if session.Step > 0 {
+			session.Step--
+			session.Result = session.History[len(session.History)-1]
+			session.History = session.History[:len(session.History)-1]
+			w.saveSession(sessionId, session)
+		}
+		err = w.showStep(itc, sessionId, session, "")
+	case wizardOpCancel:
+		w.endSession(sessionId)
+		if w.OnCancel != nil {
+			w.OnCancel(itc)
+			return
+		}
+		err = itc.Respond(discord.ResponseEditData{
+			Content: helpers.Ptr(w.label(w.Labels.Cancelled, defaultWizardLabels.Cancelled)),
+		})
+	case wizardOpSubmit, wizardOpConfirm:
+		err = w.submitStep(itc, sessionId, session)
+	default:
+		err = fmt.Errorf("unknown wizard operation: %s", op)
+	}
+
+	if err != nil {
+		fmt.Printf("wizard %s failed to handle interaction: %v\n", w.Id, err)
+	}
+}
+
+func (w Wizard[T]) submitStep(itc *InteractionContext, sessionId string, session *wizardSession[T]) error {
+	step := w.Steps[session.Step]
+
+	// Work on a copy so rejected input doesn't leak into the result
+	candidate := session.Result
+
+	if step.Submit != nil {
+		if err := step.Submit(itc, &candidate); err != nil {
+			return w.showStep(itc, sessionId, session, err.Error())
+		}
+	}
+
+	// Cloned so the working copy never writes into the history held by the stored session
+	session.History = append(slices.Clone(session.History), session.Result)
+	session.Result = candidate
+	session.Step++
+
+	if session.Step >= len(w.Steps) {
+		w.endSession(sessionId)
+
+		if w.OnComplete != nil {
+			w.OnComplete(itc, session.Result)
+		}
+
+		return nil
+	}
+
+	w.saveSession(sessionId, session)
+
+	return w.showStep(itc, sessionId, session, "")
+}
+
+func (w Wizard[T]) showStep(itc *InteractionContext, sessionId string, session *wizardSession[T], errorMessage string) error {
+	step := w.Steps[session.Step]
+
+	switch step.Type {
+	case WizardStepModal:
+		// Modals can't be shown in response to a modal submit, so ask the user to continue instead
+		if errorMessage == "" && itc.Interaction.Type != interaction_type.ModalSubmit {
+			modal, err := w.stepModal(itc, sessionId, session.Step)
+			if err != nil {
+				return err
+			}
+
+			return itc.ShowModal(Modal{Modal: modal})
+		}
+
+		return itc.Respond(discord.ResponseEditData{
+			Content: helpers.Ptr(w.stepContent(step, session, errorMessage)),
+			Components: helpers.CreateActionRow(append(
+				[]discord.MessageComponent{w.button(itc, sessionId, session.Step, wizardOpOpen, button_style.Primary, w.label(w.Labels.Continue, defaultWizardLabels.Continue))},
+				w.navigationButtons(itc, sessionId, session.Step)...,
+			)...),
+		})
+	case WizardStepSelect:
+		if step.Select == nil {
+			return fmt.Errorf("wizard %s step %d has no select menu", w.Id, session.Step)
+		}
+
+		menu := *step.Select
+		menu.CustomId = w.customId(itc, sessionId, session.Step, wizardOpSubmit)
+
+		return itc.Respond(discord.ResponseEditData{
+			Content: helpers.Ptr(w.stepContent(step, session, errorMessage)),
+			Components: []discord.MessageComponent{
+				&discord.ActionRow{Components: []discord.MessageComponent{&menu}},
+				&discord.ActionRow{Components: w.navigationButtons(itc, sessionId, session.Step)},
+			},
+		})
+	case WizardStepConfirm:
+		return itc.Respond(discord.ResponseEditData{
+			Content: helpers.Ptr(w.stepContent(step, session, errorMessage)),
+			Components: helpers.CreateActionRow(append(
+				[]discord.MessageComponent{w.button(itc, sessionId, session.Step, wizardOpConfirm, button_style.Success, w.label(w.Labels.Confirm, defaultWizardLabels.Confirm))},
+				w.navigationButtons(itc, sessionId, session.Step)...,
+			)...),
+		})
+	}
+
+	return fmt.Errorf("wizard %s step %d has unknown type: %s", w.Id, session.Step, step.Type)
+}
+
+func (w Wizard[T]) stepContent(step WizardStep[T], session *wizardSession[T], errorMessage string) string {
+	content := fmt.Sprintf("**%s** (%d/%d)", step.Title, session.Step+1, len(w.Steps))
+
+	if step.Prompt != nil {
+		content += "\n" + step.Prompt(&session.Result)
+	}
+
+	if errorMessage != "" {
+		content += "\n\n:warning: " + errorMessage
+	}
+
+	return content
+}
+
+func (w Wizard[T]) stepModal(itc *InteractionContext, sessionId string, step int) (discord.ModalCallback, error) {
+	if w.Steps[step].Modal == nil {
+		return discord.ModalCallback{}, fmt.Errorf("wizard %s step %d has no modal", w.Id, step)
+	}
+
+	modal := *w.Steps[step].Modal
+	modal.CustomId = w.customId(itc, sessionId, step, wizardOpSubmit)
+
+	return modal, nil
+}
+
+func (w Wizard[T]) navigationButtons(itc *InteractionContext, sessionId string, step int) []discord.MessageComponent {
+	buttons := make([]discord.MessageComponent, 0, 2)
+
+	if step > 0 {
+		buttons = append(buttons, w.button(itc, sessionId, step, wizardOpBack, button_style.Secondary, w.label(w.Labels.Back, defaultWizardLabels.Back)))
+	}
+
+	return append(buttons, w.button(itc, sessionId, step, wizardOpCancel, button_style.Danger, w.label(w.Labels.Cancel, defaultWizardLabels.Cancel)))
+}
+
+func (w Wizard[T]) button(itc *InteractionContext, sessionId string, step int, op string, style button_style.ButtonStyle, label string) discord.MessageComponent {
+	return &discord.Button{
+		Style:    style,
+		Label:    helpers.Ptr(label),
+		CustomId: helpers.Ptr(w.customId(itc, sessionId, step, op)),
+	}
+}
+
+func (w Wizard[T]) label(label string, fallback string) string {
+	if label == "" {
+		return fallback
+	}
+
+	return label
+}
+
+func (w Wizard[T]) store() StateStore {
+	if w.Store == nil {
+		return defaultStateStore
+	}
+
+	return w.Store
+}
+
+func (w Wizard[T]) sessionKey(sessionId string) string {
+	return "wizard:" + w.Id + ":" + sessionId
+}
+
+func (w Wizard[T]) loadSession(sessionId string) (*wizardSession[T], bool) {
+	value, ok := w.store().Get(w.sessionKey(sessionId))
+	if !ok {
+		return nil, false
+	}
+
+	session, ok := value.(wizardSession[T])
+
+	return &session, ok
+}
+
+// saveSession stores a copy of the session, changes after this aren't seen until it is saved again
+func (w Wizard[T]) saveSession(sessionId string, session *wizardSession[T]) {
+	w.store().Set(w.sessionKey(sessionId), *session)
+}
+
+func (w Wizard[T]) endSession(sessionId string) {
+	w.store().Delete(w.sessionKey(sessionId))
+}
+
+// Custom ids take the form <wizard id>:<session id>:<operation>:<step>, joined with the router's state delimiter
+func (w Wizard[T]) customId(itc *InteractionContext, sessionId string, step int, op string) string {
+	return strings.Join([]string{w.Id, sessionId, op, strconv.Itoa(step)}, itc.StateDelimiter())
+}
+
+func (w Wizard[T]) parseCustomId(itc *InteractionContext) (sessionId string, op string, step int, err error) {
+	var customId string
+
+	switch data := itc.Interaction.Data.(type) {
+	case *discord.MessageComponentData:
+		customId = data.CustomId
+	case *discord.ModalSubmitData:
+		customId = data.CustomId
+	default:
+		return "", "", 0, fmt.Errorf("wizards can only handle components and modal submits")
+	}
+
+	parts := strings.Split(customId, itc.StateDelimiter())
+	if len(parts) != 4 {
+		return "", "", 0, fmt.Errorf("invalid wizard custom id: %s", customId)
+	}
+
+	step, err = strconv.Atoi(parts[3])
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid wizard step: %w", err)
+	}
+
+	return parts[1], parts[2], step, nil
+}
@@ -0,0 +1,253 @@
+package actions
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_type"
+)
+
+type wizardTestResult struct {
+	Name string
+}
+
+type wizardTestRun struct {
+	wizard    Wizard[wizardTestResult]
+	delimiter string
+	completed *wizardTestResult
+}
+
+func newWizardTestRun(delimiter string) *wizardTestRun {
+	run := &wizardTestRun{delimiter: delimiter}
+
+	appendName := func(part string) WizardSubmitHandler[wizardTestResult] {
+		return func(itc *InteractionContext, result *wizardTestResult) error {
+			result.Name += part
+			return nil
+		}
+	}
+
+	run.wizard = Wizard[wizardTestResult]{
+		Id:    "setup",
+		Store: NewMemoryStateStore(0),
+		Steps: []WizardStep[wizardTestResult]{
+			{Type: WizardStepConfirm, Title: "First", Submit: appendName("a")},
+			{Type: WizardStepConfirm, Title: "Second", Submit: appendName("b")},
+			{Type: WizardStepConfirm, Title: "Third"},
+		},
+		OnComplete: func(itc *InteractionContext, result wizardTestResult) {
+			run.completed = &result
+		},
+	}
+
+	return run
+}
+
+func (run *wizardTestRun) context(customId string) (*InteractionContext, chan *discord.InteractionResponse) {
+	responses := make(chan *discord.InteractionResponse, 4)
+	itc := NewInteractionContext(&discord.Interaction{
+		Type: interaction_type.MessageComponent,
+		Data: &discord.MessageComponentData{CustomId: customId},
+	}, responses, false)
+	itc.SetStateDelimiter(run.delimiter)
+
+	return &itc, responses
+}
+
+func (run *wizardTestRun) start(t *testing.T) string {
+	itc, responses := run.context("")
+	if err := run.wizard.Start(itc); err != nil {
+		t.Fatal(err)
+	}
+
+	response := <-responses
+	button := response.Data.(*discord.MessageCallbackData).Components[0].(*discord.ActionRow).Components[0].(*discord.Button)
+
+	parts := strings.Split(*button.CustomId, run.delimiter)
+	if len(parts) != 4 || parts[0] != "setup" {
+		t.Fatalf("Expected a wizard custom id joined with %q, got %s", run.delimiter, *button.CustomId)
+	}
+
+	return parts[1]
+}
+
+func (run *wizardTestRun) click(sessionId string, op string, step int) string {
+	itc, responses := run.context("")
+	itc.Interaction.Data.(*discord.MessageComponentData).CustomId = run.wizard.customId(itc, sessionId, step, op)
+
+	run.wizard.Handler(itc)
+
+	select {
+	case response := <-responses:
+		if data, ok := response.Data.(*discord.MessageCallbackData); ok && data.Content != nil {
+			return *data.Content
+		}
+	default:
+	}
+
+	return ""
+}
+
+func (run *wizardTestRun) session(t *testing.T, sessionId string) *wizardSession[wizardTestResult] {
+	session, ok := run.wizard.loadSession(sessionId)
+	if !ok {
+		t.Fatalf("Expected session %s to exist", sessionId)
+	}
+
+	return session
+}
+
+func TestWizardForwardAndBack(t *testing.T) {
+	run := newWizardTestRun("|")
+	sessionId := run.start(t)
+
+	run.click(sessionId, wizardOpConfirm, 0)
+	session := run.session(t, sessionId)
+	if session.Step != 1 || session.Result.Name != "a" {
+		t.Fatalf("Expected step 1 with name a, got step %d with name %q", session.Step, session.Result.Name)
+	}
+
+	run.click(sessionId, wizardOpBack, 1)
+	session = run.session(t, sessionId)
+	if session.Step != 0 || session.Result.Name != "" || len(session.History) != 0 {
+		t.Fatalf("Expected going back to restore the first step's result, got step %d with name %q", session.Step, session.Result.Name)
+	}
+
+	run.click(sessionId, wizardOpConfirm, 0)
+	run.click(sessionId, wizardOpConfirm, 1)
+	run.click(sessionId, wizardOpConfirm, 2)
+
+	if run.completed == nil || run.completed.Name != "ab" {
+		t.Fatalf("Expected the wizard to complete with name ab, got %v", run.completed)
+	}
+	if _, ok := run.wizard.loadSession(sessionId); ok {
+		t.Errorf("Expected the session to be removed once complete")
+	}
+}
+
+func TestWizardCancel(t *testing.T) {
+	run := newWizardTestRun(":")
+	sessionId := run.start(t)
+
+	if content := run.click(sessionId, wizardOpCancel, 0); content != defaultWizardLabels.Cancelled {
+		t.Errorf("Expected the cancelled message, got %q", content)
+	}
+	if _, ok := run.wizard.loadSession(sessionId); ok {
+		t.Errorf("Expected the session to be removed once cancelled")
+	}
+}
+
+func TestWizardExpiredSession(t *testing.T) {
+	run := newWizardTestRun(":")
+
+	if content := run.click("unknown", wizardOpConfirm, 0); content != defaultWizardLabels.Expired {
+		t.Errorf("Expected the expired message, got %q", content)
+	}
+
+	itc, responses := run.context("not a wizard id")
+	run.wizard.Handler(itc)
+	if data := (<-responses).Data.(*discord.MessageCallbackData); *data.Content != defaultWizardLabels.Expired {
+		t.Errorf("Expected the expired message for a malformed id, got %q", *data.Content)
+	}
+}
+
+func TestWizardStaleStep(t *testing.T) {
+	run := newWizardTestRun(":")
+	sessionId := run.start(t)
+
+	run.click(sessionId, wizardOpConfirm, 0)
+
+	// A second click on the first step's button must not submit it again
+	content := run.click(sessionId, wizardOpConfirm, 0)
+
+	session := run.session(t, sessionId)
+	if session.Step != 1 || session.Result.Name != "a" {
+		t.Errorf("Expected a stale click to leave the session alone, got step %d with name %q", session.Step, session.Result.Name)
+	}
+	if !strings.Contains(content, "(2/3)") {
+		t.Errorf("Expected the current step to be shown again, got %q", content)
+	}
+}
+
+func TestWizardModalStepWithoutModal(t *testing.T) {
+	run := newWizardTestRun(":")
+	run.wizard.Steps[0] = WizardStep[wizardTestResult]{Type: WizardStepModal, Title: "Broken"}
+
+	itc, _ := run.context("")
+	if _, err := run.wizard.stepModal(itc, "session", 0); err == nil {
+		t.Errorf("Expected an error for a modal step without a modal")
+	}
+
+	if err := run.wizard.Start(itc); err == nil {
+		t.Errorf("Expected starting on a modal step without a modal to fail")
+	}
+}
+
+// copyingStateStore only hands out copies of what it was given, like a store that serialises its values
+type copyingStateStore struct {
+	sync.Mutex
+	entries map[string]wizardSession[wizardTestResult]
+}
+
+func (s *copyingStateStore) Get(key string) (any, bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	value, ok := s.entries[key]
+	return value, ok
+}
+
+func (s *copyingStateStore) Set(key string, value any) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.entries[key] = value.(wizardSession[wizardTestResult])
+}
+
+func (s *copyingStateStore) Delete(key string) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.entries, key)
+}
+
+func TestWizardConcurrentClicks(t *testing.T) {
+	run := newWizardTestRun(":")
+	run.wizard.Store = &copyingStateStore{entries: make(map[string]wizardSession[wizardTestResult])}
+	sessionId := run.start(t)
+
+	// Double clicks on the same button must only submit the step once, even when the store hands out copies
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run.click(sessionId, wizardOpConfirm, 0)
+		}()
+	}
+	wg.Wait()
+
+	session := run.session(t, sessionId)
+	if session.Step != 1 || session.Result.Name != "a" || len(session.History) != 1 {
+		t.Fatalf("Expected a single submit, got step %d with name %q", session.Step, session.Result.Name)
+	}
+
+	run.click(sessionId, wizardOpConfirm, 1)
+	run.click(sessionId, wizardOpConfirm, 2)
+	if run.completed == nil || run.completed.Name != "ab" {
+		t.Fatalf("Expected the wizard to complete with name ab, got %v", run.completed)
+	}
+
+	// Clicks after completion find the session gone
+	if content := run.click(sessionId, wizardOpConfirm, 2); content != defaultWizardLabels.Expired {
+		t.Errorf("Expected the expired message after completion, got %q", content)
+	}
+
+	wizardSessionLocks.mu.Lock()
+	defer wizardSessionLocks.mu.Unlock()
+	if len(wizardSessionLocks.locks) != 0 {
+		t.Errorf("Expected session locks to be released, %d remain", len(wizardSessionLocks.locks))
+	}
+}
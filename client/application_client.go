@@ -2,9 +2,17 @@ package client
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/command_type"
+	"github.com/JackHumphries9/dapper-go/discord/entitlement_owner_type"
+	"github.com/JackHumphries9/dapper-go/discord/entry_point_handler_type"
+	"github.com/JackHumphries9/dapper-go/discord/integration_type"
 	"github.com/JackHumphries9/dapper-go/discord/interaction_context_type"
 )
 
@@ -14,18 +22,19 @@ type ApplicationClient struct {
 }
 
 type CreateApplicationCommand struct {
-	Name                     string                                            `json:"name"`
-	NameLocalizations        map[string]string                                 `json:"name_localizations,omitempty"`
-	Description              *string                                           `json:"description,omitempty"`
-	DescriptionLocalizations map[string]string                                 `json:"description_localizations,omitempty"`
-	Options                  []discord.ApplicationCommandOption                `json:"options,omitempty"`
-	DefaultMemberPermissions *string                                           `json:"default_member_permissions,omitempty"`
-	DMPermission             *bool                                             `json:"dm_permission,omitempty"`
-	DefaultPermission        *bool                                             `json:"default_permission,omitempty"`
-	IntegrationTypes         []string                                          `json:"integration_types,omitempty"`
-	Contexts                 []interaction_context_type.InteractionContextType `json:"contexts,omitempty"`
-	Type                     *int                                              `json:"type,omitempty"`
-	NSFW                     *bool                                             `json:"nsfw,omitempty"`
+	Name                     string                                                 `json:"name"`
+	NameLocalizations        map[string]string                                      `json:"name_localizations,omitempty"`
+	Description              *string                                                `json:"description,omitempty"`
+	DescriptionLocalizations map[string]string                                      `json:"description_localizations,omitempty"`
+	Options                  []discord.ApplicationCommandOption                     `json:"options,omitempty"`
+	DefaultMemberPermissions *discord.Permissions                                   `json:"default_member_permissions,omitempty"`
+	DMPermission             *bool                                                  `json:"dm_permission,omitempty"`
+	DefaultPermission        *bool                                                  `json:"default_permission,omitempty"`
+	IntegrationTypes         []integration_type.IntegrationType                     `json:"integration_types,omitempty"`
+	Contexts                 []interaction_context_type.InteractionContextType      `json:"contexts,omitempty"`
+	Type                     *command_type.ApplicationCommandType                   `json:"type,omitempty"`
+	NSFW                     *bool                                                  `json:"nsfw,omitempty"`
+	Handler                  *entry_point_handler_type.EntryPointCommandHandlerType `json:"handler,omitempty"` // Only for PrimaryEntryPoint commands
 }
 
 func (appClient *ApplicationClient) MakeRequest(discordRequest DiscordRequest) (response *http.Response, err error) {
@@ -76,3 +85,210 @@ func (appClient *ApplicationClient) RegisterCommand(cmds CreateApplicationComman
 
 	return nil
 }
+
+func (appClient *ApplicationClient) ListSKUs() ([]discord.SKU, error) {
+	skus := make([]discord.SKU, 0)
+	_, err := appClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/skus",
+		Body:           nil,
+		ExpectedStatus: 200,
+		UnmarshalTo:    &skus,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return skus, nil
+}
+
+type ListEntitlementsRequest struct {
+	UserId  *discord.Snowflake
+	GuildId *discord.Snowflake
+	SkuIds  []discord.Snowflake
+	// Entitlement IDs to paginate before or after
+	Before *discord.Snowflake
+	After  *discord.Snowflake
+	// Max entitlements to fetch in one request (1-100, defaults to 100)
+	Limit        *int
+	ExcludeEnded bool
+	// Discord excludes deleted entitlements unless this is set to false
+	ExcludeDeleted *bool
+}
+
+func (appClient *ApplicationClient) ListEntitlements(request ListEntitlementsRequest) ([]discord.Entitlement, error) {
+	entitlements := make([]discord.Entitlement, 0)
+
+	query := make(url.Values)
+	if request.UserId != nil {
+		query.Add("user_id", request.UserId.String())
+	}
+	if request.GuildId != nil {
+		query.Add("guild_id", request.GuildId.String())
+	}
+	if len(request.SkuIds) > 0 {
+		skuIds := make([]string, 0, len(request.SkuIds))
+		for _, skuId := range request.SkuIds {
+			skuIds = append(skuIds, skuId.String())
+		}
+		query.Add("sku_ids", strings.Join(skuIds, ","))
+	}
+	if request.Before != nil {
+		query.Add("before", request.Before.String())
+	}
+	if request.After != nil {
+		query.Add("after", request.After.String())
+	}
+	if request.Limit != nil {
+		query.Add("limit", strconv.Itoa(*request.Limit))
+	}
+	if request.ExcludeEnded {
+		query.Add("exclude_ended", "true")
+	}
+	if request.ExcludeDeleted != nil {
+		query.Add("exclude_deleted", strconv.FormatBool(*request.ExcludeDeleted))
+	}
+	endpoint := "/entitlements"
+	encodedQuery := query.Encode()
+	if len(encodedQuery) > 0 {
+		endpoint += "?" + encodedQuery
+	}
+
+	_, err := appClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       endpoint,
+		Body:           nil,
+		ExpectedStatus: 200,
+		UnmarshalTo:    &entitlements,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entitlements, nil
+}
+
+func (appClient *ApplicationClient) GetEntitlement(entitlementId discord.Snowflake) (*discord.Entitlement, error) {
+	entitlement := &discord.Entitlement{}
+	_, err := appClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/entitlements/" + entitlementId.String(),
+		Body:           nil,
+		ExpectedStatus: 200,
+		UnmarshalTo:    entitlement,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entitlement, nil
+}
+
+// ConsumeEntitlement marks a one-time purchase (consumable SKU) entitlement as used
+func (appClient *ApplicationClient) ConsumeEntitlement(entitlementId discord.Snowflake) error {
+	_, err := appClient.MakeRequest(DiscordRequest{
+		Method:         "POST",
+		Endpoint:       "/entitlements/" + entitlementId.String() + "/consume",
+		Body:           nil,
+		ExpectedStatus: 204,
+	})
+
+	return err
+}
+
+type CreateTestEntitlementRequest struct {
+	SkuId discord.Snowflake `json:"sku_id"`
+	// Guild or user ID depending on OwnerType
+	OwnerId   discord.Snowflake                           `json:"owner_id"`
+	OwnerType entitlement_owner_type.EntitlementOwnerType `json:"owner_type"`
+}
+
+// CreateTestEntitlement grants a free entitlement for testing, the returned entitlement is partial and has no start or end date
+func (appClient *ApplicationClient) CreateTestEntitlement(request CreateTestEntitlementRequest) (*discord.Entitlement, error) {
+	body, err := json.Marshal(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entitlement := &discord.Entitlement{}
+	_, err = appClient.MakeRequest(DiscordRequest{
+		Method:         "POST",
+		Endpoint:       "/entitlements",
+		Body:           body,
+		ExpectedStatus: 200,
+		UnmarshalTo:    entitlement,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entitlement, nil
+}
+
+func (appClient *ApplicationClient) DeleteTestEntitlement(entitlementId discord.Snowflake) error {
+	_, err := appClient.MakeRequest(DiscordRequest{
+		Method:         "DELETE",
+		Endpoint:       "/entitlements/" + entitlementId.String(),
+		Body:           nil,
+		ExpectedStatus: 204,
+	})
+
+	return err
+}
+
+func (appClient *ApplicationClient) GetRoleConnectionMetadata() ([]discord.ApplicationRoleConnectionMetadata, error) {
+	metadata := make([]discord.ApplicationRoleConnectionMetadata, 0)
+
+	_, err := appClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/role-connections/metadata",
+		ExpectedStatus: 200,
+		UnmarshalTo:    &metadata,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// UpdateRoleConnectionMetadata replaces the application's role connection metadata records
+func (appClient *ApplicationClient) UpdateRoleConnectionMetadata(records []discord.ApplicationRoleConnectionMetadata) ([]discord.ApplicationRoleConnectionMetadata, error) {
+	if len(records) > discord.MaxRoleConnectionMetadataRecords {
+		return nil, fmt.Errorf("an application can have at most %d role connection metadata records", discord.MaxRoleConnectionMetadataRecords)
+	}
+
+	for _, record := range records {
+		if err := record.Verify(); err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := json.Marshal(records)
+
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make([]discord.ApplicationRoleConnectionMetadata, 0)
+
+	_, err = appClient.MakeRequest(DiscordRequest{
+		Method:         "PUT",
+		Endpoint:       "/role-connections/metadata",
+		Body:           body,
+		ExpectedStatus: 200,
+		UnmarshalTo:    &metadata,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/entitlement_owner_type"
+	"github.com/JackHumphries9/dapper-go/discord/integration_type"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_context_type"
+)
+
+func TestApplicationClient_ListSKUs(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		return 200, `[{"id": "4", "type": 5, "application_id": "1", "name": "Premium", "slug": "premium", "flags": 128}]`
+	})
+
+	skus, err := bot.GetApplicationClient(1).ListSKUs()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(skus) != 1 || skus[0].ID != 4 || skus[0].Name != "Premium" {
+		t.Errorf("Unexpected SKUs %+v", skus)
+	}
+	if request := fake.requests[0]; request.Method != "GET" || request.Path != "/applications/1/skus" {
+		t.Errorf("Unexpected request %s %s", request.Method, request.Path)
+	}
+}
+
+func TestApplicationClient_ListEntitlements(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		return 200, `[{"id": "3", "sku_id": "4", "application_id": "1", "user_id": "5", "type": 8}]`
+	})
+
+	userId := discord.Snowflake(5)
+	after := discord.Snowflake(2)
+	limit := 50
+	excludeDeleted := false
+	entitlements, err := bot.GetApplicationClient(1).ListEntitlements(ListEntitlementsRequest{
+		UserId:         &userId,
+		SkuIds:         []discord.Snowflake{4, 6},
+		After:          &after,
+		Limit:          &limit,
+		ExcludeEnded:   true,
+		ExcludeDeleted: &excludeDeleted,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entitlements) != 1 || entitlements[0].ID != 3 || *entitlements[0].UserID != 5 {
+		t.Errorf("Unexpected entitlements %+v", entitlements)
+	}
+
+	request := fake.requests[0]
+	if request.Method != "GET" || request.Path != "/applications/1/entitlements" {
+		t.Errorf("Unexpected request %s %s", request.Method, request.Path)
+	}
+
+	query, err := url.ParseQuery(request.Query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := url.Values{
+		"user_id":         {"5"},
+		"sku_ids":         {"4,6"},
+		"after":           {"2"},
+		"limit":           {"50"},
+		"exclude_ended":   {"true"},
+		"exclude_deleted": {"false"},
+	}
+	if query.Encode() != expected.Encode() {
+		t.Errorf("Expected query %s, got %s", expected.Encode(), query.Encode())
+	}
+
+	if _, err := bot.GetApplicationClient(1).ListEntitlements(ListEntitlementsRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if fake.requests[1].Query != "" {
+		t.Errorf("Expected no query without filters, got %q", fake.requests[1].Query)
+	}
+}
+
+func TestApplicationClient_EntitlementEndpoints(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		switch request.Method {
+		case "GET":
+			return 200, `{"id": "3", "sku_id": "4", "application_id": "1", "type": 8}`
+		case "POST":
+			if request.Path == "/applications/1/entitlements" {
+				return 200, `{"id": "7", "sku_id": "4", "application_id": "1", "guild_id": "10", "type": 4}`
+			}
+		}
+		return 204, ""
+	})
+	appClient := bot.GetApplicationClient(1)
+
+	entitlement, err := appClient.GetEntitlement(3)
+	if err != nil || entitlement.ID != 3 {
+		t.Errorf("Unexpected entitlement %+v (%v)", entitlement, err)
+	}
+
+	if err := appClient.ConsumeEntitlement(3); err != nil {
+		t.Error(err)
+	}
+
+	testEntitlement, err := appClient.CreateTestEntitlement(CreateTestEntitlementRequest{
+		SkuId:     4,
+		OwnerId:   10,
+		OwnerType: entitlement_owner_type.Guild,
+	})
+	if err != nil || testEntitlement.ID != 7 || testEntitlement.GuildID == nil || *testEntitlement.GuildID != 10 {
+		t.Errorf("Unexpected test entitlement %+v (%v)", testEntitlement, err)
+	}
+
+	if err := appClient.DeleteTestEntitlement(7); err != nil {
+		t.Error(err)
+	}
+
+	expected := []struct {
+		method string
+		path   string
+	}{
+		{"GET", "/applications/1/entitlements/3"},
+		{"POST", "/applications/1/entitlements/3/consume"},
+		{"POST", "/applications/1/entitlements"},
+		{"DELETE", "/applications/1/entitlements/7"},
+	}
+	if len(fake.requests) != len(expected) {
+		t.Fatalf("Expected %d requests, got %d", len(expected), len(fake.requests))
+	}
+	for i, e := range expected {
+		if fake.requests[i].Method != e.method || fake.requests[i].Path != e.path {
+			t.Errorf("request %d: expected %s %s, got %s %s", i, e.method, e.path, fake.requests[i].Method, fake.requests[i].Path)
+		}
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal([]byte(fake.requests[2].Body), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["sku_id"] != "4" || body["owner_id"] != "10" || body["owner_type"] != float64(entitlement_owner_type.Guild) {
+		t.Errorf("Unexpected test entitlement body %s", fake.requests[2].Body)
+	}
+}
+
+func TestApplicationClient_EntitlementErrors(t *testing.T) {
+	bot, _ := newFakeBot(func(request recordedRequest) (int, string) {
+		return 404, `{"message": "Unknown Entitlement", "code": 10068}`
+	})
+
+	if _, err := bot.GetApplicationClient(1).GetEntitlement(3); err == nil {
+		t.Error("Expected an error for an unknown entitlement")
+	}
+	if err := bot.GetApplicationClient(1).ConsumeEntitlement(3); err == nil {
+		t.Error("Expected an error when consuming an unknown entitlement")
+	}
+}
+
+func TestApplicationClient_RegisterCommandIntegrationTypes(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		return 201, `{}`
+	})
+
+	err := bot.GetApplicationClient(1).RegisterCommand(CreateApplicationCommand{
+		Name:             "ping",
+		IntegrationTypes: []integration_type.IntegrationType{integration_type.GuildInstall, integration_type.UserInstall},
+		Contexts:         []interaction_context_type.InteractionContextType{interaction_context_type.BOT_DM},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := fake.requests[0]
+	if request.Method != "POST" || request.Path != "/applications/1/commands" {
+		t.Errorf("Unexpected request %s %s", request.Method, request.Path)
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+		t.Fatal(err)
+	}
+	if string(body["integration_types"]) != "[0,1]" || string(body["contexts"]) != "[1]" {
+		t.Errorf("Expected integration types and contexts to be sent as numbers, got %s", request.Body)
+	}
+}
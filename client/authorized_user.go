@@ -1,6 +1,7 @@
 package client
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/JackHumphries9/dapper-go/discord"
@@ -100,3 +101,46 @@ func (authedUser *AuthorizedUser) FetchGuilds() ([]discord.Guild, error) {
 
 	return guilds, nil
 }
+
+// GetRoleConnection fetches the role connection the application (the OAuth client) has set for the user
+func (authedUser *AuthorizedUser) GetRoleConnection() (*discord.ApplicationRoleConnection, error) {
+	roleConnection := &discord.ApplicationRoleConnection{}
+
+	_, err := authedUser.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/users/@me/applications/" + authedUser.OAuthClient.ClientId + "/role-connection",
+		ExpectedStatus: 200,
+		UnmarshalTo:    roleConnection,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return roleConnection, nil
+}
+
+// UpdateRoleConnection sets the user's role connection, requires the role_connections.write scope
+func (authedUser *AuthorizedUser) UpdateRoleConnection(roleConnection discord.ApplicationRoleConnection) (*discord.ApplicationRoleConnection, error) {
+	body, err := json.Marshal(roleConnection)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &discord.ApplicationRoleConnection{}
+
+	_, err = authedUser.MakeRequest(DiscordRequest{
+		Method:         "PUT",
+		Endpoint:       "/users/@me/applications/" + authedUser.OAuthClient.ClientId + "/role-connection",
+		Body:           body,
+		ExpectedStatus: 200,
+		UnmarshalTo:    updated,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
@@ -1,16 +1,22 @@
 package client
 
 import (
+	"cmp"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/JackHumphries9/dapper-go/client/errors"
 	"github.com/JackHumphries9/dapper-go/discord"
 	"github.com/JackHumphries9/dapper-go/discord/channel_type"
+	"github.com/JackHumphries9/dapper-go/discord/reaction_type"
+	"github.com/JackHumphries9/dapper-go/helpers"
 )
 
 type ChannelClient struct {
@@ -29,7 +35,8 @@ type SendMessageData struct {
 	StickerIds       []discord.Snowflake        `json:"sticker_ids,omitempty"`
 	Attachments      []discord.Attachment       `json:"attachments,omitempty"`
 	// Only supports "SUPPRESS_EMBEDS" (1<<2) and "SUPPRESS_NOTIFICATIONS" (1<<12)
-	Flags *int `json:"flags,omitempty"`
+	Flags *int                       `json:"flags,omitempty"`
+	Poll  *discord.PollCreateRequest `json:"poll,omitempty"`
 }
 
 func (channelClient *ChannelClient) MakeRequest(discordRequest DiscordRequest) (response *http.Response, err error) {
@@ -40,6 +47,12 @@ func (channelClient *ChannelClient) MakeRequest(discordRequest DiscordRequest) (
 }
 
 func (channelClient *ChannelClient) SendMessage(messageData SendMessageData) (*discord.Message, error) {
+	if messageData.Poll != nil {
+		if err := messageData.Poll.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid poll: %w", err)
+		}
+	}
+
 	returnedMessage := &discord.Message{}
 	data, err := json.Marshal(messageData)
 	if err != nil {
@@ -402,3 +415,415 @@ func (channelClient *ChannelClient) ListThreadMembers(request ListThreadMemberRe
 
 	return threadMembers, nil
 }
+
+type GetAnswerVotersRequest struct {
+	After *discord.Snowflake // Get users after this user id
+	Limit *int               // 1-100, defaults to 25
+}
+
+type answerVotersResponse struct {
+	Users []discord.User `json:"users"`
+}
+
+// GetAnswerVoters returns a page of users who voted for an answer of the poll in the message
+func (channelClient *ChannelClient) GetAnswerVoters(messageId discord.Snowflake, answerId int, request GetAnswerVotersRequest) ([]discord.User, error) {
+	response := answerVotersResponse{}
+
+	query := make(url.Values)
+	if request.After != nil {
+		query.Add("after", request.After.String())
+	}
+	if request.Limit != nil {
+		query.Add("limit", strconv.Itoa(*request.Limit))
+	}
+	endpoint := fmt.Sprintf("/polls/%d/answers/%d", messageId, answerId)
+	encodedQuery := query.Encode()
+	if len(encodedQuery) > 0 {
+		endpoint += "?" + encodedQuery
+	}
+
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       endpoint,
+		ExpectedStatus: 200,
+		UnmarshalTo:    &response,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Users, nil
+}
+
+// GetAllAnswerVoters pages through every user who voted for an answer
+func (channelClient *ChannelClient) GetAllAnswerVoters(messageId discord.Snowflake, answerId int) ([]discord.User, error) {
+	voters := make([]discord.User, 0)
+	limit := 100
+
+	request := GetAnswerVotersRequest{Limit: &limit}
+
+	for {
+		page, err := channelClient.GetAnswerVoters(messageId, answerId, request)
+		if err != nil {
+			return nil, err
+		}
+
+		voters = append(voters, page...)
+
+		if len(page) < limit {
+			return voters, nil
+		}
+
+		request.After = &page[len(page)-1].Id
+	}
+}
+
+// EndPoll ends the poll in a message the bot sent early, returning the updated message
+func (channelClient *ChannelClient) EndPoll(messageId discord.Snowflake) (*discord.Message, error) {
+	message := &discord.Message{}
+
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "POST",
+		Endpoint:       fmt.Sprintf("/polls/%d/expire", messageId),
+		ExpectedStatus: 200,
+		UnmarshalTo:    message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+func (channelClient *ChannelClient) GetMessage(messageId discord.Snowflake) (*discord.Message, error) {
+	message := &discord.Message{}
+
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       fmt.Sprintf("/messages/%d", messageId),
+		ExpectedStatus: 200,
+		UnmarshalTo:    message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// Only one of Around, Before or After can be set
+type GetMessagesRequest struct {
+	Around *discord.Snowflake
+	Before *discord.Snowflake
+	After  *discord.Snowflake
+	Limit  *int // 1-100, defaults to 50
+}
+
+// GetMessages returns a page of the channel's messages, newest first
+func (channelClient *ChannelClient) GetMessages(request GetMessagesRequest) ([]discord.Message, error) {
+	messages := make([]discord.Message, 0)
+
+	query := make(url.Values)
+	if request.Around != nil {
+		query.Add("around", request.Around.String())
+	}
+	if request.Before != nil {
+		query.Add("before", request.Before.String())
+	}
+	if request.After != nil {
+		query.Add("after", request.After.String())
+	}
+	if request.Limit != nil {
+		query.Add("limit", strconv.Itoa(*request.Limit))
+	}
+	endpoint := "/messages"
+	encodedQuery := query.Encode()
+	if len(encodedQuery) > 0 {
+		endpoint += "?" + encodedQuery
+	}
+
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       endpoint,
+		ExpectedStatus: 200,
+		UnmarshalTo:    &messages,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// MessageHistory iterates over the channel's messages, fetching pages of request.Limit (default 100) as needed.
+// With After set messages are yielded oldest first, otherwise newest first starting at Before (or the latest message).
+// Around only yields the single page around the message. Iteration stops at the first error.
+func (channelClient *ChannelClient) MessageHistory(request GetMessagesRequest) iter.Seq2[discord.Message, error] {
+	return func(yield func(discord.Message, error) bool) {
+		// Each iteration pages from the start with its own cursor
+		request := request
+
+		if request.Limit == nil {
+			request.Limit = helpers.Ptr(100)
+		}
+
+		for {
+			page, err := channelClient.GetMessages(request)
+			if err != nil {
+				yield(discord.Message{}, err)
+				return
+			}
+
+			if request.After != nil {
+				slices.SortFunc(page, func(a, b discord.Message) int {
+					return cmp.Compare(a.Id, b.Id)
+				})
+			}
+
+			for _, message := range page {
+				if !yield(message, nil) {
+					return
+				}
+			}
+
+			if request.Around != nil || len(page) < *request.Limit {
+				return
+			}
+
+			if request.After != nil {
+				request.After = &page[len(page)-1].Id
+			} else {
+				request.Before = &page[len(page)-1].Id
+			}
+		}
+	}
+}
+
+// BulkDeleteMaxAge is how old a message can be and still be bulk deleted
+const BulkDeleteMaxAge = 14 * 24 * time.Hour
+
+// BulkDelete deletes the messages in batches of 100. Messages older than 14 days can't be bulk deleted,
+// so they are skipped and returned for the caller to delete individually if needed
+func (channelClient *ChannelClient) BulkDelete(messageIds []discord.Snowflake) (skipped []discord.Snowflake, err error) {
+	skipped = make([]discord.Snowflake, 0)
+	deletable := make([]discord.Snowflake, 0, len(messageIds))
+
+	// Leave a minute of leeway so messages don't age out between checking and deleting them
+	oldest := time.Now().Add(-BulkDeleteMaxAge + time.Minute)
+
+	for _, id := range messageIds {
+		if id.Timestamp().Before(oldest) {
+			skipped = append(skipped, id)
+		} else {
+			deletable = append(deletable, id)
+		}
+	}
+
+	for batch := range slices.Chunk(deletable, 100) {
+		// The bulk delete endpoint needs at least 2 messages
+		if len(batch) == 1 {
+			if err := channelClient.DeleteMessage(batch[0]); err != nil {
+				return skipped, err
+			}
+			continue
+		}
+
+		body, err := json.Marshal(map[string][]discord.Snowflake{"messages": batch})
+		if err != nil {
+			return skipped, err
+		}
+
+		_, err = channelClient.MakeRequest(DiscordRequest{
+			Method:         "POST",
+			Endpoint:       "/messages/bulk-delete",
+			Body:           body,
+			ExpectedStatus: 204,
+		})
+		if err != nil {
+			return skipped, err
+		}
+	}
+
+	return skipped, nil
+}
+
+func (channelClient *ChannelClient) GetPinnedMessages() ([]discord.Message, error) {
+	messages := make([]discord.Message, 0)
+
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/pins",
+		ExpectedStatus: 200,
+		UnmarshalTo:    &messages,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (channelClient *ChannelClient) PinMessage(messageId discord.Snowflake, reason string) error {
+	return channelClient.setPinned("PUT", messageId, reason)
+}
+
+func (channelClient *ChannelClient) UnpinMessage(messageId discord.Snowflake, reason string) error {
+	return channelClient.setPinned("DELETE", messageId, reason)
+}
+
+func (channelClient *ChannelClient) setPinned(method string, messageId discord.Snowflake, reason string) error {
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:            method,
+		Endpoint:          fmt.Sprintf("/pins/%d", messageId),
+		ExpectedStatus:    204,
+		AdditionalHeaders: auditLogReasonHeaders(reason),
+	})
+
+	return err
+}
+
+// CrosspostMessage publishes a message in an announcement channel to the channels following it
+func (channelClient *ChannelClient) CrosspostMessage(messageId discord.Snowflake) (*discord.Message, error) {
+	message := &discord.Message{}
+
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "POST",
+		Endpoint:       fmt.Sprintf("/messages/%d/crosspost", messageId),
+		ExpectedStatus: 200,
+		UnmarshalTo:    message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// TriggerTyping shows the bot as typing for 10 seconds or until it sends a message
+func (channelClient *ChannelClient) TriggerTyping() error {
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "POST",
+		Endpoint:       "/typing",
+		ExpectedStatus: 204,
+	})
+
+	return err
+}
+
+func reactionEndpoint(messageId discord.Snowflake, emoji discord.Emoji) string {
+	return fmt.Sprintf("/messages/%d/reactions/%s", messageId, emoji.URLEncoded())
+}
+
+// CreateReaction reacts to a message as the bot
+func (channelClient *ChannelClient) CreateReaction(messageId discord.Snowflake, emoji discord.Emoji) error {
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "PUT",
+		Endpoint:       reactionEndpoint(messageId, emoji) + "/@me",
+		ExpectedStatus: 204,
+	})
+
+	return err
+}
+
+func (channelClient *ChannelClient) DeleteOwnReaction(messageId discord.Snowflake, emoji discord.Emoji) error {
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "DELETE",
+		Endpoint:       reactionEndpoint(messageId, emoji) + "/@me",
+		ExpectedStatus: 204,
+	})
+
+	return err
+}
+
+// DeleteUserReaction removes another user's reaction, requires MANAGE_MESSAGES
+func (channelClient *ChannelClient) DeleteUserReaction(messageId discord.Snowflake, emoji discord.Emoji, userId discord.Snowflake) error {
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "DELETE",
+		Endpoint:       reactionEndpoint(messageId, emoji) + "/" + userId.String(),
+		ExpectedStatus: 204,
+	})
+
+	return err
+}
+
+type GetReactionsRequest struct {
+	Type  *reaction_type.ReactionType // Defaults to normal reactions
+	After *discord.Snowflake          // Get users after this user id
+	Limit *int                        // 1-100, defaults to 25
+}
+
+// GetReactions returns a page of the users that reacted with the emoji
+func (channelClient *ChannelClient) GetReactions(messageId discord.Snowflake, emoji discord.Emoji, request GetReactionsRequest) ([]discord.User, error) {
+	users := make([]discord.User, 0)
+
+	query := make(url.Values)
+	if request.Type != nil {
+		query.Add("type", strconv.Itoa(int(*request.Type)))
+	}
+	if request.After != nil {
+		query.Add("after", request.After.String())
+	}
+	if request.Limit != nil {
+		query.Add("limit", strconv.Itoa(*request.Limit))
+	}
+	endpoint := reactionEndpoint(messageId, emoji)
+	encodedQuery := query.Encode()
+	if len(encodedQuery) > 0 {
+		endpoint += "?" + encodedQuery
+	}
+
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       endpoint,
+		ExpectedStatus: 200,
+		UnmarshalTo:    &users,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetAllReactions pages through every user that reacted with the emoji
+func (channelClient *ChannelClient) GetAllReactions(messageId discord.Snowflake, emoji discord.Emoji, reactionType reaction_type.ReactionType) ([]discord.User, error) {
+	users := make([]discord.User, 0)
+	limit := 100
+
+	request := GetReactionsRequest{Type: &reactionType, Limit: &limit}
+
+	for {
+		page, err := channelClient.GetReactions(messageId, emoji, request)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, page...)
+
+		if len(page) < limit {
+			return users, nil
+		}
+
+		request.After = &page[len(page)-1].Id
+	}
+}
+
+func (channelClient *ChannelClient) DeleteAllReactions(messageId discord.Snowflake) error {
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "DELETE",
+		Endpoint:       fmt.Sprintf("/messages/%d/reactions", messageId),
+		ExpectedStatus: 204,
+	})
+
+	return err
+}
+
+func (channelClient *ChannelClient) DeleteAllReactionsForEmoji(messageId discord.Snowflake, emoji discord.Emoji) error {
+	_, err := channelClient.MakeRequest(DiscordRequest{
+		Method:         "DELETE",
+		Endpoint:       reactionEndpoint(messageId, emoji),
+		ExpectedStatus: 204,
+	})
+
+	return err
+}
@@ -0,0 +1,282 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/reaction_type"
+)
+
+type recordedRequest struct {
+	Method      string
+	Path        string
+	EscapedPath string
+	Query       string
+	Header      http.Header
+	Body        string
+}
+
+// fakeDiscord answers every request with the handler's status and JSON body and records what was sent
+type fakeDiscord struct {
+	requests []recordedRequest
+	handler  func(request recordedRequest) (int, string)
+}
+
+func (fake *fakeDiscord) RoundTrip(request *http.Request) (*http.Response, error) {
+	recorded := recordedRequest{
+		Method:      request.Method,
+		Path:        strings.TrimPrefix(request.URL.Path, "/api/v10"),
+		EscapedPath: strings.TrimPrefix(request.URL.EscapedPath(), "/api/v10"),
+		Query:       request.URL.RawQuery,
+		Header:      request.Header,
+	}
+	if request.Body != nil {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		recorded.Body = string(body)
+	}
+	fake.requests = append(fake.requests, recorded)
+
+	status, body := 204, ""
+	if fake.handler != nil {
+		status, body = fake.handler(recorded)
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    request,
+	}, nil
+}
+
+func newFakeBot(handler func(request recordedRequest) (int, string)) (*BotClient, *fakeDiscord) {
+	fake := &fakeDiscord{handler: handler}
+	return &BotClient{Token: "token", Client: &http.Client{Transport: fake}}, fake
+}
+
+func messagesJson(ids ...discord.Snowflake) string {
+	messages := make([]discord.Message, len(ids))
+	for i, id := range ids {
+		messages[i] = discord.Message{Id: id}
+	}
+	data, _ := json.Marshal(messages)
+	return string(data)
+}
+
+func TestChannelClient_MessageHistory(t *testing.T) {
+	limit := 2
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		switch {
+		case !strings.Contains(request.Query, "before"):
+			return 200, messagesJson(10, 9)
+		case strings.Contains(request.Query, "before=9"):
+			return 200, messagesJson(8, 7)
+		default:
+			return 200, messagesJson(6)
+		}
+	})
+	history := bot.GetChannelClient(1).MessageHistory(GetMessagesRequest{Limit: &limit})
+
+	collect := func() []discord.Snowflake {
+		ids := make([]discord.Snowflake, 0)
+		for message, err := range history {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ids = append(ids, message.Id)
+		}
+		return ids
+	}
+
+	// Ranging twice must start over instead of resuming from the last cursor
+	for run := 0; run < 2; run++ {
+		ids := collect()
+		if fmt.Sprint(ids) != "[10 9 8 7 6]" {
+			t.Errorf("run %d: got %v, want [10 9 8 7 6]", run, ids)
+		}
+	}
+
+	if len(fake.requests) != 6 {
+		t.Fatalf("expected 6 requests, got %d", len(fake.requests))
+	}
+	if fake.requests[3].Query != "limit=2" {
+		t.Errorf("second iteration should start without a cursor, got query %q", fake.requests[3].Query)
+	}
+	if fake.requests[0].Path != "/channels/1/messages" {
+		t.Errorf("unexpected path %q", fake.requests[0].Path)
+	}
+}
+
+func TestChannelClient_MessageHistoryAfter(t *testing.T) {
+	limit := 2
+	after := discord.Snowflake(1)
+	bot, _ := newFakeBot(func(request recordedRequest) (int, string) {
+		if strings.Contains(request.Query, "after=1&") {
+			// Pages come back newest first, history must still yield oldest first
+			return 200, messagesJson(3, 2)
+		}
+		return 200, messagesJson(4)
+	})
+
+	ids := make([]discord.Snowflake, 0)
+	for message, err := range bot.GetChannelClient(1).MessageHistory(GetMessagesRequest{After: &after, Limit: &limit}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, message.Id)
+	}
+
+	if fmt.Sprint(ids) != "[2 3 4]" {
+		t.Errorf("got %v, want [2 3 4]", ids)
+	}
+}
+
+func TestChannelClient_MessageHistoryError(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		return 403, `{"message": "Missing Access", "code": 50001}`
+	})
+
+	count := 0
+	for _, err := range bot.GetChannelClient(1).MessageHistory(GetMessagesRequest{}) {
+		count++
+		if err == nil {
+			t.Error("expected an error")
+		}
+	}
+
+	if count != 1 || len(fake.requests) != 1 {
+		t.Errorf("expected a single error and request, got %d yields and %d requests", count, len(fake.requests))
+	}
+}
+
+func TestChannelClient_BulkDelete(t *testing.T) {
+	bot, fake := newFakeBot(nil)
+
+	now := time.Now()
+	ids := make([]discord.Snowflake, 0, 203)
+	for i := 0; i < 201; i++ {
+		ids = append(ids, discord.SnowflakeFromTime(now.Add(-time.Duration(i)*time.Second)))
+	}
+	tooOld := discord.SnowflakeFromTime(now.Add(-BulkDeleteMaxAge - time.Hour))
+	// Inside the window but within the leeway minute, so it could expire before the request lands
+	almostTooOld := discord.SnowflakeFromTime(now.Add(-BulkDeleteMaxAge + 30*time.Second))
+	ids = append(ids, tooOld, almostTooOld)
+
+	skipped, err := bot.GetChannelClient(1).BulkDelete(ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(skipped) != 2 || skipped[0] != tooOld || skipped[1] != almostTooOld {
+		t.Errorf("expected the two old messages to be skipped, got %v", skipped)
+	}
+
+	if len(fake.requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(fake.requests))
+	}
+
+	for i, request := range fake.requests[:2] {
+		if request.Method != "POST" || request.Path != "/channels/1/messages/bulk-delete" {
+			t.Errorf("request %d: got %s %s", i, request.Method, request.Path)
+		}
+		var body struct {
+			Messages []discord.Snowflake `json:"messages"`
+		}
+		if err := json.Unmarshal([]byte(request.Body), &body); err != nil {
+			t.Fatalf("request %d: invalid body: %v", i, err)
+		}
+		if len(body.Messages) != 100 {
+			t.Errorf("request %d: expected a batch of 100, got %d", i, len(body.Messages))
+		}
+	}
+
+	// A lone leftover message can't go through bulk delete
+	last := fake.requests[2]
+	if last.Method != "DELETE" || last.Path != fmt.Sprintf("/channels/1/messages/%d", ids[200]) {
+		t.Errorf("expected a single delete for the last message, got %s %s", last.Method, last.Path)
+	}
+}
+
+func TestChannelClient_BulkDeleteAllTooOld(t *testing.T) {
+	bot, fake := newFakeBot(nil)
+
+	old := discord.SnowflakeFromTime(time.Now().Add(-BulkDeleteMaxAge - time.Hour))
+	skipped, err := bot.GetChannelClient(1).BulkDelete([]discord.Snowflake{old})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(skipped) != 1 || len(fake.requests) != 0 {
+		t.Errorf("expected the message to be skipped without any requests, got %v and %d requests", skipped, len(fake.requests))
+	}
+}
+
+func TestChannelClient_Pins(t *testing.T) {
+	bot, fake := newFakeBot(nil)
+	channelClient := bot.GetChannelClient(1)
+
+	if err := channelClient.PinMessage(2, "important"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := channelClient.UnpinMessage(2, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pin, unpin := fake.requests[0], fake.requests[1]
+	if pin.Method != "PUT" || pin.Path != "/channels/1/pins/2" || pin.Header.Get("X-Audit-Log-Reason") != "important" {
+		t.Errorf("unexpected pin request %s %s reason %q", pin.Method, pin.Path, pin.Header.Get("X-Audit-Log-Reason"))
+	}
+	if unpin.Method != "DELETE" || unpin.Path != "/channels/1/pins/2" {
+		t.Errorf("unexpected unpin request %s %s", unpin.Method, unpin.Path)
+	}
+	if _, ok := unpin.Header["X-Audit-Log-Reason"]; ok {
+		t.Error("an empty reason should not send the audit log header")
+	}
+}
+
+func TestChannelClient_Reactions(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		if request.Method == "GET" {
+			return 200, "[]"
+		}
+		return 204, ""
+	})
+	channelClient := bot.GetChannelClient(1)
+
+	thumbsUp := "👍"
+	custom := "blobcat"
+	id := discord.Snowflake(123)
+	limit := 10
+	burst := reaction_type.Burst
+
+	if err := channelClient.CreateReaction(2, discord.Emoji{Name: &thumbsUp}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := channelClient.DeleteUserReaction(2, discord.Emoji{Name: &custom, Id: &id}, 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := channelClient.GetReactions(2, discord.Emoji{Name: &custom, Id: &id}, GetReactionsRequest{Type: &burst, Limit: &limit}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct{ method, path, query string }{
+		{"PUT", "/channels/1/messages/2/reactions/%F0%9F%91%8D/@me", ""},
+		{"DELETE", "/channels/1/messages/2/reactions/blobcat:123/5", ""},
+		{"GET", "/channels/1/messages/2/reactions/blobcat:123", "limit=10&type=1"},
+	}
+	for i, want := range expected {
+		got := fake.requests[i]
+		if got.Method != want.method || got.EscapedPath != want.path || got.Query != want.query {
+			t.Errorf("request %d: got %s %s?%s, want %s %s?%s", i, got.Method, got.EscapedPath, got.Query, want.method, want.path, want.query)
+		}
+	}
+}
@@ -1,13 +1,26 @@
 package client
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/audit_log_event"
+	"github.com/JackHumphries9/dapper-go/discord/auto_moderation_event_type"
+	"github.com/JackHumphries9/dapper-go/discord/auto_moderation_trigger_type"
+	"github.com/JackHumphries9/dapper-go/discord/scheduled_event_entity_type"
+	"github.com/JackHumphries9/dapper-go/discord/scheduled_event_privacy_level"
+	"github.com/JackHumphries9/dapper-go/discord/scheduled_event_status"
+	"github.com/JackHumphries9/dapper-go/helpers"
 )
 
 type GuildClient struct {
@@ -165,3 +178,1163 @@ func (guildClient *GuildClient) JoinUserToGuild(authedUser *AuthorizedUser, user
 
 	return nil
 }
+
+type ModifyCurrentMemberData struct {
+	Nick *string
+	// Sends nick: null, resetting the bot's nickname to its username
+	ResetNick bool
+
+	Reason string
+}
+
+// ModifyCurrentMember changes the bot's own nickname in the guild
+func (guildClient *GuildClient) ModifyCurrentMember(modifyData ModifyCurrentMemberData) (*discord.Member, error) {
+	body := make(map[string]any)
+	if modifyData.ResetNick {
+		body["nick"] = nil
+	} else if modifyData.Nick != nil {
+		body["nick"] = *modifyData.Nick
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling member data to JSON: %w", err)
+	}
+
+	member := &discord.Member{}
+	_, err = guildClient.MakeRequest(DiscordRequest{
+		Method:            "PATCH",
+		Endpoint:          "/members/@me",
+		Body:              data,
+		ExpectedStatus:    200,
+		UnmarshalTo:       member,
+		AdditionalHeaders: auditLogReasonHeaders(modifyData.Reason),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func auditLogReasonHeaders(reason string) map[string]string {
+	additionalHeaders := map[string]string{}
+	if len(reason) > 0 {
+		additionalHeaders["X-Audit-Log-Reason"] = reason
+	}
+	return additionalHeaders
+}
+
+type ListBansRequest struct {
+	Before *discord.Snowflake
+	After  *discord.Snowflake
+	// 1-1000, defaults to 1000
+	Limit *int
+}
+
+func (guildClient *GuildClient) GetBans(request ListBansRequest) ([]discord.Ban, error) {
+	bans := make([]discord.Ban, 0)
+
+	query := make(url.Values)
+	if request.Before != nil {
+		query.Add("before", request.Before.String())
+	}
+	if request.After != nil {
+		query.Add("after", request.After.String())
+	}
+	if request.Limit != nil {
+		query.Add("limit", strconv.Itoa(*request.Limit))
+	}
+	endpoint := "/bans"
+	encodedQuery := query.Encode()
+	if len(encodedQuery) > 0 {
+		endpoint += "?" + encodedQuery
+	}
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       endpoint,
+		ExpectedStatus: 200,
+		UnmarshalTo:    &bans,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bans, nil
+}
+
+// GetAllBans pages through every ban in the guild
+func (guildClient *GuildClient) GetAllBans() ([]discord.Ban, error) {
+	bans := make([]discord.Ban, 0)
+	limit := 1000
+
+	request := ListBansRequest{Limit: &limit}
+
+	for {
+		page, err := guildClient.GetBans(request)
+		if err != nil {
+			return nil, err
+		}
+
+		bans = append(bans, page...)
+
+		if len(page) < limit {
+			return bans, nil
+		}
+
+		request.After = &page[len(page)-1].User.Id
+	}
+}
+
+func (guildClient *GuildClient) GetBan(userId discord.Snowflake) (*discord.Ban, error) {
+	ban := &discord.Ban{}
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/bans/" + userId.String(),
+		ExpectedStatus: 200,
+		UnmarshalTo:    ban,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ban, nil
+}
+
+// MaxBanDeleteMessageSeconds is the furthest back (7 days) a ban can delete the user's messages
+const MaxBanDeleteMessageSeconds = 604800
+
+type CreateBanData struct {
+	// How many seconds of the user's messages to delete, 0-604800
+	DeleteMessageSeconds *int `json:"delete_message_seconds,omitempty"`
+
+	Reason string `json:"-"`
+}
+
+func (guildClient *GuildClient) CreateBan(userId discord.Snowflake, banData CreateBanData) error {
+	if banData.DeleteMessageSeconds != nil && (*banData.DeleteMessageSeconds < 0 || *banData.DeleteMessageSeconds > MaxBanDeleteMessageSeconds) {
+		return fmt.Errorf("delete message seconds must be between 0 and %d", MaxBanDeleteMessageSeconds)
+	}
+
+	body, err := json.Marshal(banData)
+	if err != nil {
+		return fmt.Errorf("error marshaling ban data to JSON: %w", err)
+	}
+
+	_, err = guildClient.MakeRequest(DiscordRequest{
+		Method:            "PUT",
+		Endpoint:          "/bans/" + userId.String(),
+		Body:              body,
+		ExpectedStatus:    204,
+		AdditionalHeaders: auditLogReasonHeaders(banData.Reason),
+	})
+
+	return err
+}
+
+func (guildClient *GuildClient) RemoveBan(userId discord.Snowflake, reason string) error {
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:            "DELETE",
+		Endpoint:          "/bans/" + userId.String(),
+		ExpectedStatus:    204,
+		AdditionalHeaders: auditLogReasonHeaders(reason),
+	})
+
+	return err
+}
+
+// MaxBulkBanUsers is how many users can be banned in one bulk ban
+const MaxBulkBanUsers = 200
+
+type BulkBanData struct {
+	UserIds []discord.Snowflake `json:"user_ids"`
+	// How many seconds of the users' messages to delete, 0-604800
+	DeleteMessageSeconds *int `json:"delete_message_seconds,omitempty"`
+
+	Reason string `json:"-"`
+}
+
+// BulkBanResponse lists which users were banned, users that were already banned or couldn't be banned are in FailedUsers
+type BulkBanResponse struct {
+	BannedUsers []discord.Snowflake `json:"banned_users"`
+	FailedUsers []discord.Snowflake `json:"failed_users"`
+}
+
+func (guildClient *GuildClient) BulkBan(banData BulkBanData) (*BulkBanResponse, error) {
+	if len(banData.UserIds) < 1 || len(banData.UserIds) > MaxBulkBanUsers {
+		return nil, fmt.Errorf("bulk ban must have between 1 and %d users (you have %d)", MaxBulkBanUsers, len(banData.UserIds))
+	}
+
+	if banData.DeleteMessageSeconds != nil && (*banData.DeleteMessageSeconds < 0 || *banData.DeleteMessageSeconds > MaxBanDeleteMessageSeconds) {
+		return nil, fmt.Errorf("delete message seconds must be between 0 and %d", MaxBanDeleteMessageSeconds)
+	}
+
+	body, err := json.Marshal(banData)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling bulk ban data to JSON: %w", err)
+	}
+
+	response := &BulkBanResponse{}
+	_, err = guildClient.MakeRequest(DiscordRequest{
+		Method:            "POST",
+		Endpoint:          "/bulk-ban",
+		Body:              body,
+		ExpectedStatus:    200,
+		UnmarshalTo:       response,
+		AdditionalHeaders: auditLogReasonHeaders(banData.Reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+type SearchMembersRequest struct {
+	// Matched against the start of usernames and nicknames
+	Query string
+	// 1-1000, defaults to 1
+	Limit *int
+}
+
+func (guildClient *GuildClient) SearchMembers(request SearchMembersRequest) ([]discord.Member, error) {
+	members := make([]discord.Member, 0)
+
+	query := make(url.Values)
+	query.Add("query", request.Query)
+	if request.Limit != nil {
+		query.Add("limit", strconv.Itoa(*request.Limit))
+	}
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/members/search?" + query.Encode(),
+		ExpectedStatus: 200,
+		UnmarshalTo:    &members,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+type PruneCountRequest struct {
+	// Days of inactivity, 1-30, defaults to 7
+	Days *int
+	// By default members with roles are never pruned, members with these roles are included
+	IncludeRoles []discord.Snowflake
+}
+
+type pruneResponse struct {
+	Pruned *int `json:"pruned"`
+}
+
+// GetPruneCount returns how many members a prune with the same options would remove
+func (guildClient *GuildClient) GetPruneCount(request PruneCountRequest) (int, error) {
+	if request.Days != nil && (*request.Days < 1 || *request.Days > 30) {
+		return 0, fmt.Errorf("prune days must be between 1 and 30")
+	}
+
+	response := pruneResponse{}
+
+	query := make(url.Values)
+	if request.Days != nil {
+		query.Add("days", strconv.Itoa(*request.Days))
+	}
+	if len(request.IncludeRoles) > 0 {
+		roles := make([]string, 0, len(request.IncludeRoles))
+		for _, role := range request.IncludeRoles {
+			roles = append(roles, role.String())
+		}
+		query.Add("include_roles", strings.Join(roles, ","))
+	}
+	endpoint := "/prune"
+	encodedQuery := query.Encode()
+	if len(encodedQuery) > 0 {
+		endpoint += "?" + encodedQuery
+	}
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       endpoint,
+		ExpectedStatus: 200,
+		UnmarshalTo:    &response,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if response.Pruned == nil {
+		return 0, nil
+	}
+
+	return *response.Pruned, nil
+}
+
+type BeginPruneData struct {
+	// Days of inactivity, 1-30, defaults to 7
+	Days *int `json:"days,omitempty"`
+	// Whether to return the pruned count, false is recommended for large guilds
+	ComputePruneCount *bool               `json:"compute_prune_count,omitempty"`
+	IncludeRoles      []discord.Snowflake `json:"include_roles,omitempty"`
+
+	Reason string `json:"-"`
+}
+
+// BeginPrune kicks inactive members, returning how many were removed unless ComputePruneCount is false
+func (guildClient *GuildClient) BeginPrune(pruneData BeginPruneData) (*int, error) {
+	if pruneData.Days != nil && (*pruneData.Days < 1 || *pruneData.Days > 30) {
+		return nil, fmt.Errorf("prune days must be between 1 and 30")
+	}
+
+	body, err := json.Marshal(pruneData)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling prune data to JSON: %w", err)
+	}
+
+	response := pruneResponse{}
+	_, err = guildClient.MakeRequest(DiscordRequest{
+		Method:            "POST",
+		Endpoint:          "/prune",
+		Body:              body,
+		ExpectedStatus:    200,
+		UnmarshalTo:       &response,
+		AdditionalHeaders: auditLogReasonHeaders(pruneData.Reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Pruned, nil
+}
+
+func (guildClient *GuildClient) GetRoles() ([]discord.Role, error) {
+	roles := make([]discord.Role, 0)
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/roles",
+		ExpectedStatus: 200,
+		UnmarshalTo:    &roles,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (guildClient *GuildClient) GetRole(roleId discord.Snowflake) (*discord.Role, error) {
+	role := &discord.Role{}
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/roles/" + roleId.String(),
+		ExpectedStatus: 200,
+		UnmarshalTo:    role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// RoleData is used to create and modify roles, only the fields that are set are sent
+type RoleData struct {
+	Name        *string
+	Permissions *discord.Permissions
+	Color       *int
+	Hoist       *bool
+	Mentionable *bool
+
+	// Role icons need the guild to have the ROLE_ICONS feature
+	IconBytes    []byte
+	UnicodeEmoji *string
+	// Sends icon: null, removing the role's icon
+	RemoveIcon bool
+
+	Reason string
+}
+
+func (roleData RoleData) ToJson() ([]byte, error) {
+	body := make(map[string]any)
+
+	if roleData.Name != nil {
+		body["name"] = *roleData.Name
+	}
+	if roleData.Permissions != nil {
+		body["permissions"] = *roleData.Permissions
+	}
+	if roleData.Color != nil {
+		body["color"] = *roleData.Color
+	}
+	if roleData.Hoist != nil {
+		body["hoist"] = *roleData.Hoist
+	}
+	if roleData.Mentionable != nil {
+		body["mentionable"] = *roleData.Mentionable
+	}
+	if roleData.UnicodeEmoji != nil {
+		body["unicode_emoji"] = *roleData.UnicodeEmoji
+	}
+
+	if roleData.RemoveIcon {
+		body["icon"] = nil
+	} else if roleData.IconBytes != nil {
+		icon, err := helpers.ImageDataURI(roleData.IconBytes)
+		if err != nil {
+			return nil, err
+		}
+		body["icon"] = icon
+	}
+
+	return json.Marshal(body)
+}
+
+func (guildClient *GuildClient) CreateRole(roleData RoleData) (*discord.Role, error) {
+	return guildClient.sendRole("POST", "/roles", roleData)
+}
+
+func (guildClient *GuildClient) ModifyRole(roleId discord.Snowflake, roleData RoleData) (*discord.Role, error) {
+	return guildClient.sendRole("PATCH", "/roles/"+roleId.String(), roleData)
+}
+
+func (guildClient *GuildClient) sendRole(method string, endpoint string, roleData RoleData) (*discord.Role, error) {
+	body, err := roleData.ToJson()
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling role data to JSON: %w", err)
+	}
+
+	role := &discord.Role{}
+	_, err = guildClient.MakeRequest(DiscordRequest{
+		Method:            method,
+		Endpoint:          endpoint,
+		Body:              body,
+		ExpectedStatus:    200,
+		UnmarshalTo:       role,
+		AdditionalHeaders: auditLogReasonHeaders(roleData.Reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (guildClient *GuildClient) DeleteRole(roleId discord.Snowflake, reason string) error {
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:            "DELETE",
+		Endpoint:          "/roles/" + roleId.String(),
+		ExpectedStatus:    204,
+		AdditionalHeaders: auditLogReasonHeaders(reason),
+	})
+
+	return err
+}
+
+type RolePosition struct {
+	Id       discord.Snowflake `json:"id"`
+	Position *int              `json:"position,omitempty"`
+}
+
+// ModifyRolePositions moves the roles, returning every role in the guild with its new position
+func (guildClient *GuildClient) ModifyRolePositions(positions []RolePosition, reason string) ([]discord.Role, error) {
+	body, err := json.Marshal(positions)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling role positions to JSON: %w", err)
+	}
+
+	roles := make([]discord.Role, 0)
+	_, err = guildClient.MakeRequest(DiscordRequest{
+		Method:            "PATCH",
+		Endpoint:          "/roles",
+		Body:              body,
+		ExpectedStatus:    200,
+		UnmarshalTo:       &roles,
+		AdditionalHeaders: auditLogReasonHeaders(reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+type GetAuditLogRequest struct {
+	// Only entries made by this user
+	UserId     *discord.Snowflake
+	ActionType *audit_log_event.AuditLogEvent
+	Before     *discord.Snowflake
+	After      *discord.Snowflake
+	// 1-100, defaults to 50
+	Limit *int
+}
+
+func (guildClient *GuildClient) GetAuditLog(request GetAuditLogRequest) (*discord.AuditLog, error) {
+	auditLog := &discord.AuditLog{}
+
+	query := make(url.Values)
+	if request.UserId != nil {
+		query.Add("user_id", request.UserId.String())
+	}
+	if request.ActionType != nil {
+		query.Add("action_type", strconv.Itoa(int(*request.ActionType)))
+	}
+	if request.Before != nil {
+		query.Add("before", request.Before.String())
+	}
+	if request.After != nil {
+		query.Add("after", request.After.String())
+	}
+	if request.Limit != nil {
+		query.Add("limit", strconv.Itoa(*request.Limit))
+	}
+	endpoint := "/audit-logs"
+	encodedQuery := query.Encode()
+	if len(encodedQuery) > 0 {
+		endpoint += "?" + encodedQuery
+	}
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       endpoint,
+		ExpectedStatus: 200,
+		UnmarshalTo:    auditLog,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return auditLog, nil
+}
+
+// AuditLogPages iterates over the audit log a page of request.Limit (default 100) entries at a time, so the users,
+// webhooks and threads each page references are kept with it. With After set pages go oldest first, otherwise newest
+// first starting at Before. Iteration stops at the first error.
+func (guildClient *GuildClient) AuditLogPages(request GetAuditLogRequest) iter.Seq2[*discord.AuditLog, error] {
+	return func(yield func(*discord.AuditLog, error) bool) {
+		// Each iteration pages from the start with its own cursor
+		request := request
+
+		if request.Limit == nil {
+			request.Limit = helpers.Ptr(100)
+		}
+
+		for {
+			page, err := guildClient.GetAuditLog(request)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			entries := page.AuditLogEntries
+			if len(entries) == 0 {
+				return
+			}
+
+			if request.After != nil {
+				slices.SortFunc(entries, func(a, b discord.AuditLogEntry) int {
+					return cmp.Compare(a.Id, b.Id)
+				})
+			}
+
+			if !yield(page, nil) {
+				return
+			}
+
+			if len(entries) < *request.Limit {
+				return
+			}
+
+			if request.After != nil {
+				request.After = &entries[len(entries)-1].Id
+			} else {
+				request.Before = &entries[len(entries)-1].Id
+			}
+		}
+	}
+}
+
+func (guildClient *GuildClient) GetAutoModerationRules() ([]discord.AutoModerationRule, error) {
+	rules := make([]discord.AutoModerationRule, 0)
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/auto-moderation/rules",
+		ExpectedStatus: 200,
+		UnmarshalTo:    &rules,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (guildClient *GuildClient) GetAutoModerationRule(ruleId discord.Snowflake) (*discord.AutoModerationRule, error) {
+	rule := &discord.AutoModerationRule{}
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/auto-moderation/rules/" + ruleId.String(),
+		ExpectedStatus: 200,
+		UnmarshalTo:    rule,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+type CreateAutoModerationRuleData struct {
+	Name            string                                                 `json:"name"`
+	EventType       auto_moderation_event_type.AutoModerationEventType     `json:"event_type"`
+	TriggerType     auto_moderation_trigger_type.AutoModerationTriggerType `json:"trigger_type"`
+	TriggerMetadata *discord.AutoModerationTriggerMetadata                 `json:"trigger_metadata,omitempty"`
+	Actions         []discord.AutoModerationAction                         `json:"actions"`
+	// Defaults to false
+	Enabled        *bool               `json:"enabled,omitempty"`
+	ExemptRoles    []discord.Snowflake `json:"exempt_roles,omitempty"`
+	ExemptChannels []discord.Snowflake `json:"exempt_channels,omitempty"`
+	Reason         string              `json:"-"`
+}
+
+func (createData CreateAutoModerationRuleData) Verify() error {
+	if length := utf8.RuneCountInString(createData.Name); length < 1 || length > discord.MaxAutoModerationRuleNameLength {
+		return fmt.Errorf("auto moderation rule name must be between 1 and %d characters (you have %d)", discord.MaxAutoModerationRuleNameLength, length)
+	}
+
+	if createData.TriggerType == auto_moderation_trigger_type.MemberProfile && createData.EventType != auto_moderation_event_type.MemberUpdate {
+		return fmt.Errorf("member profile rules must use the member update event type")
+	}
+
+	if err := discord.VerifyAutoModerationTrigger(createData.TriggerType, createData.TriggerMetadata); err != nil {
+		return err
+	}
+
+	if len(createData.Actions) == 0 {
+		return fmt.Errorf("auto moderation rule must have at least one action")
+	}
+
+	for _, action := range createData.Actions {
+		if err := action.Verify(createData.TriggerType); err != nil {
+			return err
+		}
+	}
+
+	return discord.VerifyAutoModerationExemptions(createData.ExemptRoles, createData.ExemptChannels)
+}
+
+func (guildClient *GuildClient) CreateAutoModerationRule(createData CreateAutoModerationRuleData) (*discord.AutoModerationRule, error) {
+	if err := createData.Verify(); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(createData)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling data to JSON: %w", err)
+	}
+
+	rule := &discord.AutoModerationRule{}
+	_, err = guildClient.MakeRequest(DiscordRequest{
+		Method:            "POST",
+		Endpoint:          "/auto-moderation/rules",
+		Body:              body,
+		ExpectedStatus:    200,
+		UnmarshalTo:       rule,
+		AdditionalHeaders: auditLogReasonHeaders(createData.Reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// ModifyAutoModerationRuleData only sends the fields that are set, nil slices leave them unchanged
+type ModifyAutoModerationRuleData struct {
+	// The rule's trigger type, which can't be changed. It is only used to verify the metadata and actions,
+	// leave it unset to skip those checks
+	TriggerType auto_moderation_trigger_type.AutoModerationTriggerType
+
+	Name            *string
+	EventType       *auto_moderation_event_type.AutoModerationEventType
+	TriggerMetadata *discord.AutoModerationTriggerMetadata
+	Actions         []discord.AutoModerationAction
+	Enabled         *bool
+	ExemptRoles     []discord.Snowflake
+	ExemptChannels  []discord.Snowflake
+
+	Reason string
+}
+
+func (modifyData ModifyAutoModerationRuleData) Verify() error {
+	if modifyData.Name != nil {
+		if length := utf8.RuneCountInString(*modifyData.Name); length < 1 || length > discord.MaxAutoModerationRuleNameLength {
+			return fmt.Errorf("auto moderation rule name must be between 1 and %d characters (you have %d)", discord.MaxAutoModerationRuleNameLength, length)
+		}
+	}
+
+	if modifyData.Actions != nil && len(modifyData.Actions) == 0 {
+		return fmt.Errorf("auto moderation rule must have at least one action")
+	}
+
+	if modifyData.TriggerType != 0 {
+		// New metadata replaces the old, so it needs everything the trigger type requires
+		if modifyData.TriggerMetadata != nil {
+			if err := discord.VerifyAutoModerationTrigger(modifyData.TriggerType, modifyData.TriggerMetadata); err != nil {
+				return err
+			}
+		}
+
+		for _, action := range modifyData.Actions {
+			if err := action.Verify(modifyData.TriggerType); err != nil {
+				return err
+			}
+		}
+	}
+
+	return discord.VerifyAutoModerationExemptions(modifyData.ExemptRoles, modifyData.ExemptChannels)
+}
+
+func (modifyData ModifyAutoModerationRuleData) ToJson() ([]byte, error) {
+	body := make(map[string]any)
+
+	if modifyData.Name != nil {
+		body["name"] = *modifyData.Name
+	}
+	if modifyData.EventType != nil {
+		body["event_type"] = *modifyData.EventType
+	}
+	if modifyData.TriggerMetadata != nil {
+		body["trigger_metadata"] = *modifyData.TriggerMetadata
+	}
+	if modifyData.Actions != nil {
+		body["actions"] = modifyData.Actions
+	}
+	if modifyData.Enabled != nil {
+		body["enabled"] = *modifyData.Enabled
+	}
+	if modifyData.ExemptRoles != nil {
+		body["exempt_roles"] = modifyData.ExemptRoles
+	}
+	if modifyData.ExemptChannels != nil {
+		body["exempt_channels"] = modifyData.ExemptChannels
+	}
+
+	return json.Marshal(body)
+}
+
+func (guildClient *GuildClient) ModifyAutoModerationRule(ruleId discord.Snowflake, modifyData ModifyAutoModerationRuleData) (*discord.AutoModerationRule, error) {
+	if err := modifyData.Verify(); err != nil {
+		return nil, err
+	}
+
+	body, err := modifyData.ToJson()
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling data to JSON: %w", err)
+	}
+
+	rule := &discord.AutoModerationRule{}
+	_, err = guildClient.MakeRequest(DiscordRequest{
+		Method:            "PATCH",
+		Endpoint:          "/auto-moderation/rules/" + ruleId.String(),
+		Body:              body,
+		ExpectedStatus:    200,
+		UnmarshalTo:       rule,
+		AdditionalHeaders: auditLogReasonHeaders(modifyData.Reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (guildClient *GuildClient) DeleteAutoModerationRule(ruleId discord.Snowflake, reason string) error {
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:            "DELETE",
+		Endpoint:          "/auto-moderation/rules/" + ruleId.String(),
+		ExpectedStatus:    204,
+		AdditionalHeaders: auditLogReasonHeaders(reason),
+	})
+
+	return err
+}
+
+func (guildClient *GuildClient) GetScheduledEvents(withUserCount bool) ([]discord.GuildScheduledEvent, error) {
+	events := make([]discord.GuildScheduledEvent, 0)
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/scheduled-events?with_user_count=" + strconv.FormatBool(withUserCount),
+		ExpectedStatus: 200,
+		UnmarshalTo:    &events,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (guildClient *GuildClient) GetScheduledEvent(eventId discord.Snowflake, withUserCount bool) (*discord.GuildScheduledEvent, error) {
+	event := &discord.GuildScheduledEvent{}
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       "/scheduled-events/" + eventId.String() + "?with_user_count=" + strconv.FormatBool(withUserCount),
+		ExpectedStatus: 200,
+		UnmarshalTo:    event,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+type CreateScheduledEventData struct {
+	// Required for stage and voice events
+	ChannelId *discord.Snowflake
+	// Required for external events
+	EntityMetadata *discord.ScheduledEventEntityMetadata
+	Name           string
+	// Defaults to GuildOnly, the only privacy level
+	PrivacyLevel       *scheduled_event_privacy_level.ScheduledEventPrivacyLevel
+	ScheduledStartTime time.Time
+	// Required for external events
+	ScheduledEndTime *time.Time
+	Description      *string
+	EntityType       scheduled_event_entity_type.ScheduledEventEntityType
+	ImageBytes       []byte
+	RecurrenceRule   *discord.RecurrenceRule
+
+	Reason string
+}
+
+func (createData CreateScheduledEventData) Verify() error {
+	if length := utf8.RuneCountInString(createData.Name); length < 1 || length > discord.MaxScheduledEventNameLength {
+		return fmt.Errorf("event name must be between 1 and %d characters (you have %d)", discord.MaxScheduledEventNameLength, length)
+	}
+
+	if createData.Description != nil {
+		if length := utf8.RuneCountInString(*createData.Description); length > discord.MaxScheduledEventDescriptionLength {
+			return fmt.Errorf("event description cannot be longer than %d characters (you have %d)", discord.MaxScheduledEventDescriptionLength, length)
+		}
+	}
+
+	if createData.ScheduledStartTime.IsZero() {
+		return fmt.Errorf("event must have a start time")
+	}
+
+	if createData.RecurrenceRule != nil {
+		if err := createData.RecurrenceRule.Verify(); err != nil {
+			return err
+		}
+	}
+
+	return discord.VerifyScheduledEventEntity(createData.EntityType, createData.ChannelId, createData.EntityMetadata,
+		createData.ScheduledStartTime, createData.ScheduledEndTime)
+}
+
+func (createData CreateScheduledEventData) ToJson() ([]byte, error) {
+	privacyLevel := scheduled_event_privacy_level.GuildOnly
+	if createData.PrivacyLevel != nil {
+		privacyLevel = *createData.PrivacyLevel
+	}
+
+	body := map[string]any{
+		"name":                 createData.Name,
+		"privacy_level":        privacyLevel,
+		"scheduled_start_time": createData.ScheduledStartTime.UTC().Format(time.RFC3339),
+		"entity_type":          createData.EntityType,
+	}
+
+	if createData.ChannelId != nil {
+		body["channel_id"] = *createData.ChannelId
+	}
+	if createData.EntityMetadata != nil {
+		body["entity_metadata"] = *createData.EntityMetadata
+	}
+	if createData.ScheduledEndTime != nil {
+		body["scheduled_end_time"] = createData.ScheduledEndTime.UTC().Format(time.RFC3339)
+	}
+	if createData.Description != nil {
+		body["description"] = *createData.Description
+	}
+	if createData.RecurrenceRule != nil {
+		body["recurrence_rule"] = *createData.RecurrenceRule
+	}
+	if createData.ImageBytes != nil {
+		image, err := helpers.ImageDataURI(createData.ImageBytes)
+		if err != nil {
+			return nil, err
+		}
+		body["image"] = image
+	}
+
+	return json.Marshal(body)
+}
+
+func (guildClient *GuildClient) CreateScheduledEvent(createData CreateScheduledEventData) (*discord.GuildScheduledEvent, error) {
+	if err := createData.Verify(); err != nil {
+		return nil, err
+	}
+
+	body, err := createData.ToJson()
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling data to JSON: %w", err)
+	}
+
+	event := &discord.GuildScheduledEvent{}
+	_, err = guildClient.MakeRequest(DiscordRequest{
+		Method:            "POST",
+		Endpoint:          "/scheduled-events",
+		Body:              body,
+		ExpectedStatus:    200,
+		UnmarshalTo:       event,
+		AdditionalHeaders: auditLogReasonHeaders(createData.Reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ModifyScheduledEventData only sends the fields that are set. Changing an event to External also needs
+// EntityMetadata with a location and ScheduledEndTime and no ChannelId, its channel is removed automatically
+type ModifyScheduledEventData struct {
+	ChannelId          *discord.Snowflake
+	EntityMetadata     *discord.ScheduledEventEntityMetadata
+	Name               *string
+	PrivacyLevel       *scheduled_event_privacy_level.ScheduledEventPrivacyLevel
+	ScheduledStartTime *time.Time
+	ScheduledEndTime   *time.Time
+	Description        *string
+	EntityType         *scheduled_event_entity_type.ScheduledEventEntityType
+	// Scheduled events can be started or cancelled and active events completed. Verify only knows the new
+	// status so it can't check where the event is moving from, use SetScheduledEventStatus for the full check
+	Status         *scheduled_event_status.ScheduledEventStatus
+	ImageBytes     []byte
+	RecurrenceRule *discord.RecurrenceRule
+	// Sends recurrence_rule: null, making the event a one-off
+	RemoveRecurrence bool
+
+	Reason string
+}
+
+func (modifyData ModifyScheduledEventData) Verify() error {
+	if modifyData.Name != nil {
+		if length := utf8.RuneCountInString(*modifyData.Name); length < 1 || length > discord.MaxScheduledEventNameLength {
+			return fmt.Errorf("event name must be between 1 and %d characters (you have %d)", discord.MaxScheduledEventNameLength, length)
+		}
+	}
+
+	if modifyData.Description != nil {
+		if length := utf8.RuneCountInString(*modifyData.Description); length > discord.MaxScheduledEventDescriptionLength {
+			return fmt.Errorf("event description cannot be longer than %d characters (you have %d)", discord.MaxScheduledEventDescriptionLength, length)
+		}
+	}
+
+	if modifyData.RecurrenceRule != nil {
+		if err := modifyData.RecurrenceRule.Verify(); err != nil {
+			return err
+		}
+	}
+
+	// No transition leads back to Scheduled, so only the statuses an event can move to are allowed
+	if modifyData.Status != nil {
+		switch *modifyData.Status {
+		case scheduled_event_status.Active, scheduled_event_status.Completed, scheduled_event_status.Canceled:
+		default:
+			return fmt.Errorf("event status can only be changed to active, completed or canceled (got %d)", *modifyData.Status)
+		}
+	}
+
+	// Only a change of entity type is checked, the event's other fields aren't known here
+	if modifyData.EntityType != nil {
+		var startTime time.Time
+		if modifyData.ScheduledStartTime != nil {
+			startTime = *modifyData.ScheduledStartTime
+		}
+
+		return discord.VerifyScheduledEventEntity(*modifyData.EntityType, modifyData.ChannelId, modifyData.EntityMetadata,
+			startTime, modifyData.ScheduledEndTime)
+	}
+
+	return nil
+}
+
+func (modifyData ModifyScheduledEventData) ToJson() ([]byte, error) {
+	body := make(map[string]any)
+
+	if modifyData.EntityType != nil {
+		body["entity_type"] = *modifyData.EntityType
+	}
+	if modifyData.EntityType != nil && *modifyData.EntityType == scheduled_event_entity_type.External {
+		body["channel_id"] = nil
+	} else if modifyData.ChannelId != nil {
+		body["channel_id"] = *modifyData.ChannelId
+	}
+	if modifyData.EntityMetadata != nil {
+		body["entity_metadata"] = *modifyData.EntityMetadata
+	}
+	if modifyData.Name != nil {
+		body["name"] = *modifyData.Name
+	}
+	if modifyData.PrivacyLevel != nil {
+		body["privacy_level"] = *modifyData.PrivacyLevel
+	}
+	if modifyData.ScheduledStartTime != nil {
+		body["scheduled_start_time"] = modifyData.ScheduledStartTime.UTC().Format(time.RFC3339)
+	}
+	if modifyData.ScheduledEndTime != nil {
+		body["scheduled_end_time"] = modifyData.ScheduledEndTime.UTC().Format(time.RFC3339)
+	}
+	if modifyData.Description != nil {
+		body["description"] = *modifyData.Description
+	}
+	if modifyData.Status != nil {
+		body["status"] = *modifyData.Status
+	}
+	if modifyData.RemoveRecurrence {
+		body["recurrence_rule"] = nil
+	} else if modifyData.RecurrenceRule != nil {
+		body["recurrence_rule"] = *modifyData.RecurrenceRule
+	}
+	if modifyData.ImageBytes != nil {
+		image, err := helpers.ImageDataURI(modifyData.ImageBytes)
+		if err != nil {
+			return nil, err
+		}
+		body["image"] = image
+	}
+
+	return json.Marshal(body)
+}
+
+func (guildClient *GuildClient) ModifyScheduledEvent(eventId discord.Snowflake, modifyData ModifyScheduledEventData) (*discord.GuildScheduledEvent, error) {
+	if err := modifyData.Verify(); err != nil {
+		return nil, err
+	}
+
+	body, err := modifyData.ToJson()
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling data to JSON: %w", err)
+	}
+
+	event := &discord.GuildScheduledEvent{}
+	_, err = guildClient.MakeRequest(DiscordRequest{
+		Method:            "PATCH",
+		Endpoint:          "/scheduled-events/" + eventId.String(),
+		Body:              body,
+		ExpectedStatus:    200,
+		UnmarshalTo:       event,
+		AdditionalHeaders: auditLogReasonHeaders(modifyData.Reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// SetScheduledEventStatus starts, completes or cancels the event, checking the change is one Discord allows from its current status
+func (guildClient *GuildClient) SetScheduledEventStatus(event discord.GuildScheduledEvent, status scheduled_event_status.ScheduledEventStatus, reason string) (*discord.GuildScheduledEvent, error) {
+	if !event.Status.CanTransitionTo(status) {
+		return nil, fmt.Errorf("event status cannot change from %d to %d", event.Status, status)
+	}
+
+	return guildClient.ModifyScheduledEvent(event.Id, ModifyScheduledEventData{
+		Status: &status,
+		Reason: reason,
+	})
+}
+
+func (guildClient *GuildClient) DeleteScheduledEvent(eventId discord.Snowflake) error {
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "DELETE",
+		Endpoint:       "/scheduled-events/" + eventId.String(),
+		ExpectedStatus: 204,
+	})
+
+	return err
+}
+
+type GetScheduledEventUsersRequest struct {
+	// 1-100, defaults to 100
+	Limit      *int
+	WithMember bool
+	Before     *discord.Snowflake
+	After      *discord.Snowflake
+}
+
+func (guildClient *GuildClient) GetScheduledEventUsers(eventId discord.Snowflake, request GetScheduledEventUsersRequest) ([]discord.GuildScheduledEventUser, error) {
+	users := make([]discord.GuildScheduledEventUser, 0)
+
+	query := make(url.Values)
+	if request.Limit != nil {
+		query.Add("limit", strconv.Itoa(*request.Limit))
+	}
+	if request.WithMember {
+		query.Add("with_member", "true")
+	}
+	if request.Before != nil {
+		query.Add("before", request.Before.String())
+	}
+	if request.After != nil {
+		query.Add("after", request.After.String())
+	}
+	endpoint := "/scheduled-events/" + eventId.String() + "/users"
+	encodedQuery := query.Encode()
+	if len(encodedQuery) > 0 {
+		endpoint += "?" + encodedQuery
+	}
+
+	_, err := guildClient.MakeRequest(DiscordRequest{
+		Method:         "GET",
+		Endpoint:       endpoint,
+		ExpectedStatus: 200,
+		UnmarshalTo:    &users,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetAllScheduledEventUsers fetches every user subscribed to the event, 100 at a time
+func (guildClient *GuildClient) GetAllScheduledEventUsers(eventId discord.Snowflake, withMember bool) ([]discord.GuildScheduledEventUser, error) {
+	users := make([]discord.GuildScheduledEventUser, 0)
+	limit := 100
+
+	request := GetScheduledEventUsersRequest{Limit: &limit, WithMember: withMember}
+
+	for {
+		page, err := guildClient.GetScheduledEventUsers(eventId, request)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, page...)
+
+		if len(page) < limit {
+			return users, nil
+		}
+
+		// Pages aren't guaranteed to be sorted, so continue after the highest user id
+		last := slices.MaxFunc(page, func(a, b discord.GuildScheduledEventUser) int {
+			return cmp.Compare(a.User.Id, b.User.Id)
+		})
+		request.After = &last.User.Id
+	}
+}
@@ -0,0 +1,459 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/auto_moderation_action_type"
+	"github.com/JackHumphries9/dapper-go/discord/auto_moderation_event_type"
+	"github.com/JackHumphries9/dapper-go/discord/auto_moderation_trigger_type"
+	"github.com/JackHumphries9/dapper-go/discord/scheduled_event_entity_type"
+	"github.com/JackHumphries9/dapper-go/discord/scheduled_event_status"
+)
+
+func TestGuildClient_AuditLogPages(t *testing.T) {
+	limit := 2
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		if !strings.Contains(request.Query, "before") {
+			return 200, `{"audit_log_entries": [{"id": "5"}, {"id": "4"}]}`
+		}
+		return 200, `{"audit_log_entries": [{"id": "3"}]}`
+	})
+	pages := bot.GetGuildClient(1).AuditLogPages(GetAuditLogRequest{Limit: &limit})
+
+	// Ranging twice must start over instead of resuming from the last cursor
+	for run := 0; run < 2; run++ {
+		ids := make([]discord.Snowflake, 0)
+		for page, err := range pages {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, entry := range page.AuditLogEntries {
+				ids = append(ids, entry.Id)
+			}
+		}
+		if fmt.Sprint(ids) != "[5 4 3]" {
+			t.Errorf("run %d: got %v, want [5 4 3]", run, ids)
+		}
+	}
+
+	if len(fake.requests) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(fake.requests))
+	}
+	if fake.requests[2].Query != "limit=2" {
+		t.Errorf("second iteration should start without a cursor, got query %q", fake.requests[2].Query)
+	}
+	if fake.requests[1].Query != "before=4&limit=2" {
+		t.Errorf("expected the next page before the oldest entry, got query %q", fake.requests[1].Query)
+	}
+}
+
+func TestModifyScheduledEventData_Verify(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	end := start.Add(time.Hour)
+	external := scheduled_event_entity_type.External
+	channelId := discord.Snowflake(1)
+	location := "The park"
+
+	toExternal := ModifyScheduledEventData{
+		EntityType:         &external,
+		EntityMetadata:     &discord.ScheduledEventEntityMetadata{Location: &location},
+		ScheduledStartTime: &start,
+		ScheduledEndTime:   &end,
+	}
+	if err := toExternal.Verify(); err != nil {
+		t.Errorf("Expected a change to an external event to be valid, got %v", err)
+	}
+
+	body, err := toExternal.ToJson()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `"channel_id":null`) {
+		t.Errorf("Expected the channel to be removed, got %s", body)
+	}
+
+	// The channel would be dropped from the request, so refuse it instead
+	toExternal.ChannelId = &channelId
+	if err := toExternal.Verify(); err == nil {
+		t.Error("Expected an external event with a channel to be invalid")
+	}
+}
+
+func TestModifyScheduledEventData_VerifyStatus(t *testing.T) {
+	for _, status := range []scheduled_event_status.ScheduledEventStatus{scheduled_event_status.Active, scheduled_event_status.Completed, scheduled_event_status.Canceled} {
+		if err := (ModifyScheduledEventData{Status: &status}).Verify(); err != nil {
+			t.Errorf("Expected a change to status %d to be valid, got %v", status, err)
+		}
+	}
+
+	for _, status := range []scheduled_event_status.ScheduledEventStatus{scheduled_event_status.Scheduled, 0, 5} {
+		if err := (ModifyScheduledEventData{Status: &status}).Verify(); err == nil {
+			t.Errorf("Expected a change to status %d to be invalid", status)
+		}
+	}
+}
+
+func TestGuildClient_SetScheduledEventStatus(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		return 200, `{"id": "7", "status": 2}`
+	})
+	guildClient := bot.GetGuildClient(1)
+
+	scheduled := discord.GuildScheduledEvent{Id: 7, Status: scheduled_event_status.Scheduled}
+	event, err := guildClient.SetScheduledEventStatus(scheduled, scheduled_event_status.Active, "starting")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Status != scheduled_event_status.Active {
+		t.Errorf("expected the event to be active, got %d", event.Status)
+	}
+
+	request := fake.requests[0]
+	if request.Method != "PATCH" || request.Path != "/guilds/1/scheduled-events/7" || request.Body != `{"status":2}` {
+		t.Errorf("unexpected request %s %s %s", request.Method, request.Path, request.Body)
+	}
+	if request.Header.Get("X-Audit-Log-Reason") != "starting" {
+		t.Errorf("expected the audit log reason, got %q", request.Header.Get("X-Audit-Log-Reason"))
+	}
+
+	invalid := []struct {
+		from scheduled_event_status.ScheduledEventStatus
+		to   scheduled_event_status.ScheduledEventStatus
+	}{
+		{scheduled_event_status.Scheduled, scheduled_event_status.Completed},
+		{scheduled_event_status.Active, scheduled_event_status.Canceled},
+		{scheduled_event_status.Completed, scheduled_event_status.Active},
+		{scheduled_event_status.Canceled, scheduled_event_status.Active},
+	}
+	for _, transition := range invalid {
+		event := discord.GuildScheduledEvent{Id: 7, Status: transition.from}
+		if _, err := guildClient.SetScheduledEventStatus(event, transition.to, ""); err == nil {
+			t.Errorf("expected a change from %d to %d to be rejected", transition.from, transition.to)
+		}
+	}
+	if len(fake.requests) != 1 {
+		t.Errorf("expected rejected changes not to be sent, got %d requests", len(fake.requests))
+	}
+}
+
+func TestScheduledEventData_VerifyCountsCharacters(t *testing.T) {
+	name := strings.Repeat("é", discord.MaxScheduledEventNameLength)
+	channelId := discord.Snowflake(1)
+
+	createData := CreateScheduledEventData{
+		ChannelId:          &channelId,
+		Name:               name,
+		ScheduledStartTime: time.Now().Add(time.Hour),
+		EntityType:         scheduled_event_entity_type.Voice,
+	}
+	if err := createData.Verify(); err != nil {
+		t.Errorf("Expected a %d character name to be valid, got %v", discord.MaxScheduledEventNameLength, err)
+	}
+
+	description := strings.Repeat("é", discord.MaxScheduledEventDescriptionLength)
+	if err := (ModifyScheduledEventData{Name: &name, Description: &description}).Verify(); err != nil {
+		t.Errorf("Expected a %d character description to be valid, got %v", discord.MaxScheduledEventDescriptionLength, err)
+	}
+
+	createData.Name += "é"
+	if err := createData.Verify(); err == nil {
+		t.Errorf("Expected a %d character name to be invalid", discord.MaxScheduledEventNameLength+1)
+	}
+}
+
+func TestCreateAutoModerationRuleData_Verify(t *testing.T) {
+	createData := CreateAutoModerationRuleData{
+		Name:        strings.Repeat("é", discord.MaxAutoModerationRuleNameLength),
+		EventType:   auto_moderation_event_type.MessageSend,
+		TriggerType: auto_moderation_trigger_type.Keyword,
+		Actions:     []discord.AutoModerationAction{{Type: auto_moderation_action_type.BlockMessage}},
+	}
+	if err := createData.Verify(); err == nil {
+		t.Error("Expected a keyword rule without keywords to be invalid")
+	}
+
+	createData.TriggerMetadata = &discord.AutoModerationTriggerMetadata{KeywordFilter: []string{"cat"}}
+	if err := createData.Verify(); err != nil {
+		t.Errorf("Expected a keyword rule with keywords to be valid, got %v", err)
+	}
+
+	modifyData := ModifyAutoModerationRuleData{
+		TriggerType:     auto_moderation_trigger_type.KeywordPreset,
+		TriggerMetadata: &discord.AutoModerationTriggerMetadata{},
+	}
+	if err := modifyData.Verify(); err == nil {
+		t.Error("Expected replacing a preset rule's metadata without presets to be invalid")
+	}
+}
+
+func TestRoleData_ToJson(t *testing.T) {
+	name := "Moderator"
+	permissions := discord.PermissionKickMembers | discord.PermissionBanMembers
+	color := 0
+	hoist := true
+	emoji := "🛡️"
+	png := []byte("\x89PNG\r\n\x1a\n")
+
+	tests := []struct {
+		name string
+		data RoleData
+		want string
+	}{
+		{"empty", RoleData{Reason: "ignored"}, `{}`},
+		{
+			"set fields",
+			RoleData{Name: &name, Permissions: &permissions, Color: &color, Hoist: &hoist, UnicodeEmoji: &emoji},
+			`{"color":0,"hoist":true,"name":"Moderator","permissions":"6","unicode_emoji":"🛡️"}`,
+		},
+		{"icon", RoleData{IconBytes: png}, `{"icon":"data:image/png;base64,iVBORw0KGgo="}`},
+		{"remove icon", RoleData{RemoveIcon: true}, `{"icon":null}`},
+		{"remove icon wins over bytes", RoleData{IconBytes: png, RemoveIcon: true}, `{"icon":null}`},
+	}
+
+	for _, test := range tests {
+		body, err := test.data.ToJson()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if string(body) != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, body, test.want)
+		}
+	}
+
+	if _, err := (RoleData{IconBytes: []byte("not an image")}).ToJson(); err == nil {
+		t.Error("expected an unsupported icon type to be rejected")
+	}
+}
+
+func TestGuildClient_Roles(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		return 200, `{"id": "3", "name": "Moderator"}`
+	})
+	guildClient := bot.GetGuildClient(1)
+
+	name := "Moderator"
+	if _, err := guildClient.CreateRole(RoleData{Name: &name, Reason: "new team"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := guildClient.ModifyRole(3, RoleData{RemoveIcon: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	create, modify := fake.requests[0], fake.requests[1]
+	if create.Method != "POST" || create.Path != "/guilds/1/roles" || create.Body != `{"name":"Moderator"}` {
+		t.Errorf("unexpected create request %s %s %s", create.Method, create.Path, create.Body)
+	}
+	if create.Header.Get("X-Audit-Log-Reason") != "new team" {
+		t.Errorf("expected the audit log reason, got %q", create.Header.Get("X-Audit-Log-Reason"))
+	}
+	if modify.Method != "PATCH" || modify.Path != "/guilds/1/roles/3" || modify.Body != `{"icon":null}` {
+		t.Errorf("unexpected modify request %s %s %s", modify.Method, modify.Path, modify.Body)
+	}
+}
+
+func bansJson(ids ...discord.Snowflake) string {
+	bans := make([]discord.Ban, len(ids))
+	for i, id := range ids {
+		bans[i] = discord.Ban{User: discord.User{Id: id}}
+	}
+	data, _ := json.Marshal(bans)
+	return string(data)
+}
+
+func TestGuildClient_GetAllBans(t *testing.T) {
+	full := make([]discord.Snowflake, 1000)
+	for i := range full {
+		full[i] = discord.Snowflake(i + 1)
+	}
+
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		if strings.Contains(request.Query, "after=1000") {
+			return 200, bansJson(1001, 1002)
+		}
+		return 200, bansJson(full...)
+	})
+
+	bans, err := bot.GetGuildClient(1).GetAllBans()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(bans) != 1002 || bans[1001].User.Id != 1002 {
+		t.Errorf("expected 1002 bans ending with user 1002, got %d", len(bans))
+	}
+	if len(fake.requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(fake.requests))
+	}
+	if fake.requests[0].Path != "/guilds/1/bans" || fake.requests[0].Query != "limit=1000" {
+		t.Errorf("unexpected first page %s?%s", fake.requests[0].Path, fake.requests[0].Query)
+	}
+	if fake.requests[1].Query != "after=1000&limit=1000" {
+		t.Errorf("expected the second page to continue after the last user, got %q", fake.requests[1].Query)
+	}
+}
+
+func TestGuildClient_Bans(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		if request.Method == "GET" {
+			return 200, `{"reason": "spam", "user": {"id": "2"}}`
+		}
+		return 204, ""
+	})
+	guildClient := bot.GetGuildClient(1)
+
+	ban, err := guildClient.GetBan(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ban.Reason == nil || *ban.Reason != "spam" || ban.User.Id != 2 {
+		t.Errorf("unexpected ban %+v", ban)
+	}
+
+	seconds := 3600
+	if err := guildClient.CreateBan(2, CreateBanData{DeleteMessageSeconds: &seconds, Reason: "spam"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := guildClient.RemoveBan(2, "appeal"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	create, remove := fake.requests[1], fake.requests[2]
+	if create.Method != "PUT" || create.Path != "/guilds/1/bans/2" || create.Body != `{"delete_message_seconds":3600}` {
+		t.Errorf("unexpected create ban request %s %s %s", create.Method, create.Path, create.Body)
+	}
+	if create.Header.Get("X-Audit-Log-Reason") != "spam" {
+		t.Errorf("expected the ban reason header, got %q", create.Header.Get("X-Audit-Log-Reason"))
+	}
+	if remove.Method != "DELETE" || remove.Path != "/guilds/1/bans/2" || remove.Header.Get("X-Audit-Log-Reason") != "appeal" {
+		t.Errorf("unexpected remove ban request %s %s", remove.Method, remove.Path)
+	}
+
+	tooLong := MaxBanDeleteMessageSeconds + 1
+	if err := guildClient.CreateBan(2, CreateBanData{DeleteMessageSeconds: &tooLong}); err == nil {
+		t.Error("expected deleting more than 7 days of messages to be rejected")
+	}
+	if len(fake.requests) != 3 {
+		t.Errorf("expected the rejected ban not to be sent, got %d requests", len(fake.requests))
+	}
+}
+
+func TestGuildClient_BulkBan(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		return 200, `{"banned_users": ["2"], "failed_users": ["3"]}`
+	})
+	guildClient := bot.GetGuildClient(1)
+
+	response, err := guildClient.BulkBan(BulkBanData{UserIds: []discord.Snowflake{2, 3}, Reason: "raid"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(response.BannedUsers) != "[2]" || fmt.Sprint(response.FailedUsers) != "[3]" {
+		t.Errorf("expected a partial success, got %+v", response)
+	}
+
+	request := fake.requests[0]
+	if request.Method != "POST" || request.Path != "/guilds/1/bulk-ban" || request.Body != `{"user_ids":["2","3"]}` {
+		t.Errorf("unexpected bulk ban request %s %s %s", request.Method, request.Path, request.Body)
+	}
+	if request.Header.Get("X-Audit-Log-Reason") != "raid" {
+		t.Errorf("expected the bulk ban reason header, got %q", request.Header.Get("X-Audit-Log-Reason"))
+	}
+
+	maxUsers := make([]discord.Snowflake, MaxBulkBanUsers)
+	for i := range maxUsers {
+		maxUsers[i] = discord.Snowflake(i + 1)
+	}
+	if _, err := guildClient.BulkBan(BulkBanData{UserIds: maxUsers}); err != nil {
+		t.Errorf("expected %d users to be allowed, got %v", MaxBulkBanUsers, err)
+	}
+
+	if _, err := guildClient.BulkBan(BulkBanData{UserIds: append(maxUsers, MaxBulkBanUsers+1)}); err == nil {
+		t.Errorf("expected more than %d users to be rejected", MaxBulkBanUsers)
+	}
+	if _, err := guildClient.BulkBan(BulkBanData{}); err == nil {
+		t.Error("expected an empty bulk ban to be rejected")
+	}
+	if len(fake.requests) != 2 {
+		t.Errorf("expected the rejected bulk bans not to be sent, got %d requests", len(fake.requests))
+	}
+}
+
+func TestGuildClient_SearchMembers(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		return 200, `[{"nick": "Kitty", "roles": [], "user": {"id": "2"}}]`
+	})
+
+	limit := 10
+	members, err := bot.GetGuildClient(1).SearchMembers(SearchMembersRequest{Query: "kit ty&", Limit: &limit})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(members) != 1 || members[0].User == nil || members[0].User.Id != 2 {
+		t.Errorf("unexpected members %+v", members)
+	}
+
+	request := fake.requests[0]
+	if request.Path != "/guilds/1/members/search" || request.Query != "limit=10&query=kit+ty%26" {
+		t.Errorf("unexpected search request %s?%s", request.Path, request.Query)
+	}
+}
+
+func TestGuildClient_Prune(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		if request.Method == "POST" && strings.Contains(request.Body, `"compute_prune_count":false`) {
+			return 200, `{"pruned": null}`
+		}
+		return 200, `{"pruned": 4}`
+	})
+	guildClient := bot.GetGuildClient(1)
+
+	days := 14
+	count, err := guildClient.GetPruneCount(PruneCountRequest{Days: &days, IncludeRoles: []discord.Snowflake{10, 11}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("expected a prune count of 4, got %d", count)
+	}
+	if fake.requests[0].Path != "/guilds/1/prune" || fake.requests[0].Query != "days=14&include_roles=10%2C11" {
+		t.Errorf("unexpected prune count request %s?%s", fake.requests[0].Path, fake.requests[0].Query)
+	}
+
+	pruned, err := guildClient.BeginPrune(BeginPruneData{Days: &days, IncludeRoles: []discord.Snowflake{10}, Reason: "cleanup"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pruned == nil || *pruned != 4 {
+		t.Errorf("expected 4 pruned members, got %v", pruned)
+	}
+	begin := fake.requests[1]
+	if begin.Method != "POST" || begin.Body != `{"days":14,"include_roles":["10"]}` || begin.Header.Get("X-Audit-Log-Reason") != "cleanup" {
+		t.Errorf("unexpected begin prune request %s %s reason %q", begin.Method, begin.Body, begin.Header.Get("X-Audit-Log-Reason"))
+	}
+
+	compute := false
+	pruned, err = guildClient.BeginPrune(BeginPruneData{ComputePruneCount: &compute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pruned != nil {
+		t.Errorf("expected no count without compute_prune_count, got %d", *pruned)
+	}
+
+	for _, invalid := range []int{0, 31} {
+		if _, err := guildClient.GetPruneCount(PruneCountRequest{Days: &invalid}); err == nil {
+			t.Errorf("expected %d days to be rejected by GetPruneCount", invalid)
+		}
+		if _, err := guildClient.BeginPrune(BeginPruneData{Days: &invalid}); err == nil {
+			t.Errorf("expected %d days to be rejected by BeginPrune", invalid)
+		}
+	}
+	if len(fake.requests) != 3 {
+		t.Errorf("expected invalid prunes not to be sent, got %d requests", len(fake.requests))
+	}
+}
@@ -1,11 +1,17 @@
 package client
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/JackHumphries9/dapper-go/discord"
 )
 
+// MaxTimeoutDuration is the longest a member can be timed out for
+const MaxTimeoutDuration = 28 * 24 * time.Hour
+
 type MemberClient struct {
 	MemberId    discord.Snowflake
 	GuildClient *GuildClient
@@ -42,17 +48,29 @@ type ModifyMemberRoleOpts struct {
 }
 
 func (memberClient *MemberClient) AddRoleToMember(opts ModifyMemberRoleOpts) error {
-	additionalHeaders := map[string]string{}
-	if len(opts.Reason) > 0 {
-		additionalHeaders["X-Audit-Log-Reason"] = opts.Reason
-	}
 	req := DiscordRequest{
 		Method:            "PUT",
 		Endpoint:          "/roles/" + opts.RoleID.String(),
 		Body:              nil,
 		ExpectedStatus:    204,
 		UnmarshalTo:       nil,
-		AdditionalHeaders: additionalHeaders,
+		AdditionalHeaders: auditLogReasonHeaders(opts.Reason),
+	}
+	_, err := memberClient.MakeRequest(req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (memberClient *MemberClient) RemoveRoleFromMember(opts ModifyMemberRoleOpts) error {
+	req := DiscordRequest{
+		Method:            "DELETE",
+		Endpoint:          "/roles/" + opts.RoleID.String(),
+		Body:              nil,
+		ExpectedStatus:    204,
+		UnmarshalTo:       nil,
+		AdditionalHeaders: auditLogReasonHeaders(opts.Reason),
 	}
 	_, err := memberClient.MakeRequest(req)
 	if err != nil {
@@ -60,3 +78,128 @@ func (memberClient *MemberClient) AddRoleToMember(opts ModifyMemberRoleOpts) err
 	}
 	return nil
 }
+
+// ModifyMemberData only sends the fields that are set. The Reset/Remove/Disconnect
+// options send an explicit null, which Discord needs to clear a value
+type ModifyMemberData struct {
+	Nick      *string
+	ResetNick bool
+
+	// Replaces all of the member's roles, nil leaves them unchanged
+	Roles []discord.Snowflake
+
+	Mute *bool
+	Deaf *bool
+
+	// Moves the member to another voice channel, they must already be connected to one
+	ChannelId       *discord.Snowflake
+	DisconnectVoice bool
+
+	// Up to 28 days in the future
+	CommunicationDisabledUntil *time.Time
+	RemoveTimeout              bool
+
+	Flags *int
+
+	Reason string
+}
+
+func (modifyData ModifyMemberData) ToJson() ([]byte, error) {
+	body := make(map[string]any)
+
+	if modifyData.ResetNick {
+		body["nick"] = nil
+	} else if modifyData.Nick != nil {
+		body["nick"] = *modifyData.Nick
+	}
+
+	if modifyData.Roles != nil {
+		body["roles"] = modifyData.Roles
+	}
+
+	if modifyData.Mute != nil {
+		body["mute"] = *modifyData.Mute
+	}
+
+	if modifyData.Deaf != nil {
+		body["deaf"] = *modifyData.Deaf
+	}
+
+	if modifyData.DisconnectVoice {
+		body["channel_id"] = nil
+	} else if modifyData.ChannelId != nil {
+		body["channel_id"] = *modifyData.ChannelId
+	}
+
+	if modifyData.RemoveTimeout {
+		body["communication_disabled_until"] = nil
+	} else if modifyData.CommunicationDisabledUntil != nil {
+		body["communication_disabled_until"] = modifyData.CommunicationDisabledUntil.UTC().Format(time.RFC3339)
+	}
+
+	if modifyData.Flags != nil {
+		body["flags"] = *modifyData.Flags
+	}
+
+	return json.Marshal(body)
+}
+
+func (memberClient *MemberClient) Modify(modifyData ModifyMemberData) (*discord.Member, error) {
+	if modifyData.CommunicationDisabledUntil != nil && time.Until(*modifyData.CommunicationDisabledUntil) > MaxTimeoutDuration {
+		return nil, fmt.Errorf("members cannot be timed out for more than 28 days")
+	}
+
+	body, err := modifyData.ToJson()
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling member data to JSON: %w", err)
+	}
+
+	member := &discord.Member{}
+	_, err = memberClient.MakeRequest(DiscordRequest{
+		Method:            "PATCH",
+		Endpoint:          "",
+		Body:              body,
+		ExpectedStatus:    200,
+		UnmarshalTo:       member,
+		AdditionalHeaders: auditLogReasonHeaders(modifyData.Reason),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// Timeout stops the member from talking or reacting for the duration, up to 28 days
+func (memberClient *MemberClient) Timeout(duration time.Duration, reason string) (*discord.Member, error) {
+	if duration <= 0 {
+		return nil, fmt.Errorf("timeout duration must be positive, use RemoveTimeout to end a timeout")
+	}
+
+	until := time.Now().Add(duration)
+
+	return memberClient.Modify(ModifyMemberData{
+		CommunicationDisabledUntil: &until,
+		Reason:                     reason,
+	})
+}
+
+func (memberClient *MemberClient) RemoveTimeout(reason string) (*discord.Member, error) {
+	return memberClient.Modify(ModifyMemberData{
+		RemoveTimeout: true,
+		Reason:        reason,
+	})
+}
+
+// Kick removes the member from the guild, they can rejoin with an invite
+func (memberClient *MemberClient) Kick(reason string) error {
+	_, err := memberClient.MakeRequest(DiscordRequest{
+		Method:            "DELETE",
+		Endpoint:          "",
+		ExpectedStatus:    204,
+		AdditionalHeaders: auditLogReasonHeaders(reason),
+	})
+
+	return err
+}
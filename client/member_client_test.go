@@ -0,0 +1,120 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+)
+
+func TestMemberClient_AuditLogReason(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		if request.Method == "PATCH" {
+			return 200, `{"roles": []}`
+		}
+		return 204, ""
+	})
+	memberClient := bot.GetGuildClient(1).GetMemberClient(2)
+
+	if err := memberClient.Kick("spam"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := memberClient.Timeout(time.Hour, "spam"); err != nil {
+		t.Fatal(err)
+	}
+	if err := memberClient.AddRoleToMember(ModifyMemberRoleOpts{RoleID: 3, Reason: "spam"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := memberClient.RemoveRoleFromMember(ModifyMemberRoleOpts{RoleID: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		method string
+		path   string
+		reason string
+	}{
+		{"DELETE", "/guilds/1/members/2", "spam"},
+		{"PATCH", "/guilds/1/members/2", "spam"},
+		{"PUT", "/guilds/1/members/2/roles/3", "spam"},
+		{"DELETE", "/guilds/1/members/2/roles/3", ""},
+	}
+
+	if len(fake.requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(fake.requests))
+	}
+
+	for i, e := range expected {
+		request := fake.requests[i]
+		if request.Method != e.method || request.Path != e.path {
+			t.Errorf("request %d: expected %s %s, got %s %s", i, e.method, e.path, request.Method, request.Path)
+		}
+
+		reason, ok := request.Header["X-Audit-Log-Reason"]
+		if e.reason == "" && ok {
+			t.Errorf("request %d: an empty reason should not send the audit log header", i)
+		}
+		if e.reason != "" && (len(reason) != 1 || reason[0] != e.reason) {
+			t.Errorf("request %d: expected reason %q, got %v", i, e.reason, reason)
+		}
+	}
+}
+
+func TestModifyMemberData_ToJson(t *testing.T) {
+	nick := "Kitty"
+	mute := false
+	channelId := discord.Snowflake(7)
+	until := time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("UTC+1", 3600))
+	flags := 0
+
+	tests := []struct {
+		name string
+		data ModifyMemberData
+		want string
+	}{
+		{"empty", ModifyMemberData{Reason: "ignored"}, `{}`},
+		{"set fields", ModifyMemberData{Nick: &nick, Mute: &mute, ChannelId: &channelId, Flags: &flags}, `{"channel_id":"7","flags":0,"mute":false,"nick":"Kitty"}`},
+		{"timeout in utc", ModifyMemberData{CommunicationDisabledUntil: &until}, `{"communication_disabled_until":"2026-01-02T02:04:05Z"}`},
+		{"empty roles clear them", ModifyMemberData{Roles: []discord.Snowflake{}}, `{"roles":[]}`},
+		{"reset nick", ModifyMemberData{ResetNick: true}, `{"nick":null}`},
+		{"disconnect voice", ModifyMemberData{DisconnectVoice: true}, `{"channel_id":null}`},
+		{"remove timeout", ModifyMemberData{RemoveTimeout: true}, `{"communication_disabled_until":null}`},
+		{
+			"resets win over values",
+			ModifyMemberData{Nick: &nick, ResetNick: true, ChannelId: &channelId, DisconnectVoice: true, CommunicationDisabledUntil: &until, RemoveTimeout: true},
+			`{"channel_id":null,"communication_disabled_until":null,"nick":null}`,
+		},
+	}
+
+	for _, test := range tests {
+		body, err := test.data.ToJson()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if string(body) != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, body, test.want)
+		}
+	}
+}
+
+func TestMemberClient_RemoveTimeout(t *testing.T) {
+	bot, fake := newFakeBot(func(request recordedRequest) (int, string) {
+		return 200, `{"roles": []}`
+	})
+	memberClient := bot.GetGuildClient(1).GetMemberClient(2)
+
+	if _, err := memberClient.RemoveTimeout(""); err != nil {
+		t.Fatal(err)
+	}
+	if fake.requests[0].Body != `{"communication_disabled_until":null}` {
+		t.Errorf("expected an explicit null timeout, got %s", fake.requests[0].Body)
+	}
+
+	tooLong := time.Now().Add(MaxTimeoutDuration + time.Hour)
+	if _, err := memberClient.Modify(ModifyMemberData{CommunicationDisabledUntil: &tooLong}); err == nil {
+		t.Error("expected a timeout over 28 days to be rejected")
+	}
+	if len(fake.requests) != 1 {
+		t.Errorf("expected the rejected timeout not to be sent, got %d requests", len(fake.requests))
+	}
+}
@@ -1,6 +1,7 @@
 package discord
 
 import (
+	"github.com/JackHumphries9/dapper-go/discord/channel_type"
 	"github.com/JackHumphries9/dapper-go/discord/command_option_type"
 )
 
@@ -25,7 +26,7 @@ type ApplicationCommandOption struct {
 	Required                 *bool                                 `json:"required,omitempty"`
 	Choices                  []ApplicationCommandOptionChoice      `json:"choices,omitempty"`
 	Options                  []ApplicationCommandOption            `json:"options,omitempty"`
-	ChannelTypes             []string                              `json:"channel_types,omitempty"`
+	ChannelTypes             []channel_type.ChannelType            `json:"channel_types,omitempty"`
 	MinValue                 *float64                              `json:"min_value,omitempty"`
 	MaxValue                 *float64                              `json:"max_value,omitempty"`
 	MinLength                *int                                  `json:"min_length,omitempty"`
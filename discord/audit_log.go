@@ -0,0 +1,231 @@
+package discord
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord/audit_log_event"
+)
+
+type AuditLog struct {
+	ApplicationCommands  []ApplicationCommand  `json:"application_commands"`
+	AuditLogEntries      []AuditLogEntry       `json:"audit_log_entries"`
+	AutoModerationRules  []AutoModerationRule  `json:"auto_moderation_rules"`
+	GuildScheduledEvents []GuildScheduledEvent `json:"guild_scheduled_events"`
+	Integrations         []AuditLogIntegration `json:"integrations"`
+	Threads              []Channel             `json:"threads"`
+	Users                []User                `json:"users"`
+	Webhooks             []Webhook             `json:"webhooks"`
+}
+
+// GetUser finds a user referenced by the audit log's entries
+func (auditLog *AuditLog) GetUser(userId Snowflake) *User {
+	for i := range auditLog.Users {
+		if auditLog.Users[i].Id == userId {
+			return &auditLog.Users[i]
+		}
+	}
+
+	return nil
+}
+
+type AuditLogEntry struct {
+	TargetId   *Snowflake                    `json:"target_id,omitempty"`
+	Changes    []AuditLogChange              `json:"changes,omitempty"`
+	UserId     *Snowflake                    `json:"user_id,omitempty"`
+	Id         Snowflake                     `json:"id"`
+	ActionType audit_log_event.AuditLogEvent `json:"action_type"`
+	Options    *AuditLogEntryInfo            `json:"options,omitempty"`
+	Reason     *string                       `json:"reason,omitempty"`
+}
+
+// GetChange finds the change to the given key, e.g. "name" or "$add"
+func (entry *AuditLogEntry) GetChange(key string) *AuditLogChange {
+	for i := range entry.Changes {
+		if entry.Changes[i].Key == key {
+			return &entry.Changes[i]
+		}
+	}
+
+	return nil
+}
+
+// AuditLogEntryInfo holds extra details for some action types, Discord sends the counts as strings
+type AuditLogEntryInfo struct {
+	ApplicationId                 *Snowflake `json:"application_id,omitempty"`
+	AutoModerationRuleName        *string    `json:"auto_moderation_rule_name,omitempty"`
+	AutoModerationRuleTriggerType *string    `json:"auto_moderation_rule_trigger_type,omitempty"`
+	ChannelId                     *Snowflake `json:"channel_id,omitempty"`
+	Count                         *string    `json:"count,omitempty"`
+	DeleteMemberDays              *string    `json:"delete_member_days,omitempty"`
+	Id                            *Snowflake `json:"id,omitempty"`
+	MembersRemoved                *string    `json:"members_removed,omitempty"`
+	MessageId                     *Snowflake `json:"message_id,omitempty"`
+	RoleName                      *string    `json:"role_name,omitempty"`
+	// "0" for a role overwrite or "1" for a member overwrite
+	Type            *string `json:"type,omitempty"`
+	IntegrationType *string `json:"integration_type,omitempty"`
+}
+
+// AuditLogRole is the partial role sent in $add and $remove changes
+type AuditLogRole struct {
+	Id   Snowflake `json:"id"`
+	Name string    `json:"name"`
+}
+
+type AuditLogIntegration struct {
+	Id            Snowflake                  `json:"id"`
+	Name          string                     `json:"name"`
+	Type          string                     `json:"type"`
+	Account       AuditLogIntegrationAccount `json:"account"`
+	ApplicationId *Snowflake                 `json:"application_id,omitempty"`
+}
+
+type AuditLogIntegrationAccount struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AuditLogChange is a single changed field. NewValue and OldValue are decoded into the field's type for known keys
+// (e.g. Permissions for "permissions", []AuditLogRole for "$add"), anything else is decoded as plain JSON
+type AuditLogChange struct {
+	Key              string           `json:"key"`
+	NewValueInternal *json.RawMessage `json:"new_value,omitempty"`
+	OldValueInternal *json.RawMessage `json:"old_value,omitempty"`
+	NewValue         any              `json:"-"`
+	OldValue         any              `json:"-"`
+}
+
+func (change *AuditLogChange) UnmarshalJSON(d []byte) error {
+	type InnerAuditLogChange AuditLogChange
+
+	var inner InnerAuditLogChange
+
+	if err := json.Unmarshal(d, &inner); err != nil {
+		return err
+	}
+
+	castChange := AuditLogChange(inner)
+
+	var err error
+	if castChange.NewValue, err = decodeAuditLogValue(castChange.Key, castChange.NewValueInternal); err != nil {
+		return err
+	}
+	if castChange.OldValue, err = decodeAuditLogValue(castChange.Key, castChange.OldValueInternal); err != nil {
+		return err
+	}
+
+	*change = castChange
+
+	return nil
+}
+
+// AuditLogChangeValues returns the change's old and new values as T, either is nil when missing or of another type
+func AuditLogChangeValues[T any](change *AuditLogChange) (oldValue *T, newValue *T) {
+	if value, ok := change.OldValue.(T); ok {
+		oldValue = &value
+	}
+	if value, ok := change.NewValue.(T); ok {
+		newValue = &value
+	}
+
+	return oldValue, newValue
+}
+
+var auditLogValueDecoders = map[string]func(raw json.RawMessage) (any, error){
+	"permissions": decodeAuditLogValueAs[Permissions],
+	"allow":       decodeAuditLogValueAs[Permissions],
+	"deny":        decodeAuditLogValueAs[Permissions],
+
+	"$add":                  decodeAuditLogValueAs[[]AuditLogRole],
+	"$remove":               decodeAuditLogValueAs[[]AuditLogRole],
+	"permission_overwrites": decodeAuditLogValueAs[[]Overwrite],
+
+	"communication_disabled_until": decodeAuditLogValueAs[time.Time],
+
+	"id":                        decodeAuditLogValueAs[Snowflake],
+	"owner_id":                  decodeAuditLogValueAs[Snowflake],
+	"channel_id":                decodeAuditLogValueAs[Snowflake],
+	"inviter_id":                decodeAuditLogValueAs[Snowflake],
+	"application_id":            decodeAuditLogValueAs[Snowflake],
+	"afk_channel_id":            decodeAuditLogValueAs[Snowflake],
+	"system_channel_id":         decodeAuditLogValueAs[Snowflake],
+	"rules_channel_id":          decodeAuditLogValueAs[Snowflake],
+	"public_updates_channel_id": decodeAuditLogValueAs[Snowflake],
+	"widget_channel_id":         decodeAuditLogValueAs[Snowflake],
+	"safety_alerts_channel_id":  decodeAuditLogValueAs[Snowflake],
+
+	"name":             decodeAuditLogValueAs[string],
+	"nick":             decodeAuditLogValueAs[string],
+	"topic":            decodeAuditLogValueAs[string],
+	"description":      decodeAuditLogValueAs[string],
+	"code":             decodeAuditLogValueAs[string],
+	"icon_hash":        decodeAuditLogValueAs[string],
+	"avatar_hash":      decodeAuditLogValueAs[string],
+	"splash_hash":      decodeAuditLogValueAs[string],
+	"banner_hash":      decodeAuditLogValueAs[string],
+	"vanity_url_code":  decodeAuditLogValueAs[string],
+	"preferred_locale": decodeAuditLogValueAs[string],
+	"rtc_region":       decodeAuditLogValueAs[string],
+	"unicode_emoji":    decodeAuditLogValueAs[string],
+
+	"color":                         decodeAuditLogValueAs[int],
+	"position":                      decodeAuditLogValueAs[int],
+	"bitrate":                       decodeAuditLogValueAs[int],
+	"user_limit":                    decodeAuditLogValueAs[int],
+	"rate_limit_per_user":           decodeAuditLogValueAs[int],
+	"auto_archive_duration":         decodeAuditLogValueAs[int],
+	"default_auto_archive_duration": decodeAuditLogValueAs[int],
+	"afk_timeout":                   decodeAuditLogValueAs[int],
+	"verification_level":            decodeAuditLogValueAs[int],
+	"explicit_content_filter":       decodeAuditLogValueAs[int],
+	"default_message_notifications": decodeAuditLogValueAs[int],
+	"mfa_level":                     decodeAuditLogValueAs[int],
+	"nsfw_level":                    decodeAuditLogValueAs[int],
+	"max_uses":                      decodeAuditLogValueAs[int],
+	"max_age":                       decodeAuditLogValueAs[int],
+	"uses":                          decodeAuditLogValueAs[int],
+	"prune_delete_days":             decodeAuditLogValueAs[int],
+	"flags":                         decodeAuditLogValueAs[int],
+
+	"hoist":                        decodeAuditLogValueAs[bool],
+	"mentionable":                  decodeAuditLogValueAs[bool],
+	"nsfw":                         decodeAuditLogValueAs[bool],
+	"deaf":                         decodeAuditLogValueAs[bool],
+	"mute":                         decodeAuditLogValueAs[bool],
+	"temporary":                    decodeAuditLogValueAs[bool],
+	"archived":                     decodeAuditLogValueAs[bool],
+	"locked":                       decodeAuditLogValueAs[bool],
+	"invitable":                    decodeAuditLogValueAs[bool],
+	"available":                    decodeAuditLogValueAs[bool],
+	"enabled":                      decodeAuditLogValueAs[bool],
+	"widget_enabled":               decodeAuditLogValueAs[bool],
+	"premium_progress_bar_enabled": decodeAuditLogValueAs[bool],
+}
+
+func decodeAuditLogValue(key string, raw *json.RawMessage) (any, error) {
+	if raw == nil || string(*raw) == "null" {
+		return nil, nil
+	}
+
+	if decode, ok := auditLogValueDecoders[key]; ok {
+		// Keys are shared between object types, so fall back to plain JSON if the value isn't the expected type
+		if value, err := decode(*raw); err == nil {
+			return value, nil
+		}
+	}
+
+	var value any
+	err := json.Unmarshal(*raw, &value)
+
+	return value, err
+}
+
+func decodeAuditLogValueAs[T any](raw json.RawMessage) (any, error) {
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,73 @@
+package audit_log_event
+
+type AuditLogEvent int
+
+const (
+	GuildUpdate                             AuditLogEvent = 1   // Server settings were updated
+	ChannelCreate                           AuditLogEvent = 10  // Channel was created
+	ChannelUpdate                           AuditLogEvent = 11  // Channel settings were updated
+	ChannelDelete                           AuditLogEvent = 12  // Channel was deleted
+	ChannelOverwriteCreate                  AuditLogEvent = 13  // Permission overwrite was added to a channel
+	ChannelOverwriteUpdate                  AuditLogEvent = 14  // Permission overwrite was updated for a channel
+	ChannelOverwriteDelete                  AuditLogEvent = 15  // Permission overwrite was deleted from a channel
+	MemberKick                              AuditLogEvent = 20  // Member was removed from the server
+	MemberPrune                             AuditLogEvent = 21  // Members were pruned from the server
+	MemberBanAdd                            AuditLogEvent = 22  // Member was banned from the server
+	MemberBanRemove                         AuditLogEvent = 23  // Server ban was lifted for a member
+	MemberUpdate                            AuditLogEvent = 24  // Member was updated in the server
+	MemberRoleUpdate                        AuditLogEvent = 25  // Member was added or removed from a role
+	MemberMove                              AuditLogEvent = 26  // Member was moved to a different voice channel
+	MemberDisconnect                        AuditLogEvent = 27  // Member was disconnected from a voice channel
+	BotAdd                                  AuditLogEvent = 28  // Bot user was added to the server
+	RoleCreate                              AuditLogEvent = 30  // Role was created
+	RoleUpdate                              AuditLogEvent = 31  // Role was edited
+	RoleDelete                              AuditLogEvent = 32  // Role was deleted
+	InviteCreate                            AuditLogEvent = 40  // Server invite was created
+	InviteUpdate                            AuditLogEvent = 41  // Server invite was updated
+	InviteDelete                            AuditLogEvent = 42  // Server invite was deleted
+	WebhookCreate                           AuditLogEvent = 50  // Webhook was created
+	WebhookUpdate                           AuditLogEvent = 51  // Webhook properties or channel were updated
+	WebhookDelete                           AuditLogEvent = 52  // Webhook was deleted
+	EmojiCreate                             AuditLogEvent = 60  // Emoji was created
+	EmojiUpdate                             AuditLogEvent = 61  // Emoji name was updated
+	EmojiDelete                             AuditLogEvent = 62  // Emoji was deleted
+	MessageDelete                           AuditLogEvent = 72  // Single message was deleted
+	MessageBulkDelete                       AuditLogEvent = 73  // Multiple messages were deleted
+	MessagePin                              AuditLogEvent = 74  // Message was pinned to a channel
+	MessageUnpin                            AuditLogEvent = 75  // Message was unpinned from a channel
+	IntegrationCreate                       AuditLogEvent = 80  // App was added to server
+	IntegrationUpdate                       AuditLogEvent = 81  // App was updated
+	IntegrationDelete                       AuditLogEvent = 82  // App was removed from server
+	StageInstanceCreate                     AuditLogEvent = 83  // Stage instance was created
+	StageInstanceUpdate                     AuditLogEvent = 84  // Stage instance details were updated
+	StageInstanceDelete                     AuditLogEvent = 85  // Stage instance was deleted
+	StickerCreate                           AuditLogEvent = 90  // Sticker was created
+	StickerUpdate                           AuditLogEvent = 91  // Sticker details were updated
+	StickerDelete                           AuditLogEvent = 92  // Sticker was deleted
+	GuildScheduledEventCreate               AuditLogEvent = 100 // Event was created
+	GuildScheduledEventUpdate               AuditLogEvent = 101 // Event was updated
+	GuildScheduledEventDelete               AuditLogEvent = 102 // Event was cancelled
+	ThreadCreate                            AuditLogEvent = 110 // Thread was created in a channel
+	ThreadUpdate                            AuditLogEvent = 111 // Thread was updated
+	ThreadDelete                            AuditLogEvent = 112 // Thread was deleted
+	ApplicationCommandPermissionUpdate      AuditLogEvent = 121 // Permissions were updated for a command
+	SoundboardSoundCreate                   AuditLogEvent = 130 // Soundboard sound was created
+	SoundboardSoundUpdate                   AuditLogEvent = 131 // Soundboard sound was updated
+	SoundboardSoundDelete                   AuditLogEvent = 132 // Soundboard sound was deleted
+	AutoModerationRuleCreate                AuditLogEvent = 140 // Auto Moderation rule was created
+	AutoModerationRuleUpdate                AuditLogEvent = 141 // Auto Moderation rule was updated
+	AutoModerationRuleDelete                AuditLogEvent = 142 // Auto Moderation rule was deleted
+	AutoModerationBlockMessage              AuditLogEvent = 143 // Message was blocked by Auto Moderation
+	AutoModerationFlagToChannel             AuditLogEvent = 144 // Message was flagged by Auto Moderation
+	AutoModerationUserCommunicationDisabled AuditLogEvent = 145 // Member was timed out by Auto Moderation
+	AutoModerationQuarantineUser            AuditLogEvent = 146 // Member was quarantined by Auto Moderation
+	CreatorMonetizationRequestCreated       AuditLogEvent = 150 // Creator monetization request was created
+	CreatorMonetizationTermsAccepted        AuditLogEvent = 151 // Creator monetization terms were accepted
+	OnboardingPromptCreate                  AuditLogEvent = 163 // Guild Onboarding Question was created
+	OnboardingPromptUpdate                  AuditLogEvent = 164 // Guild Onboarding Question was updated
+	OnboardingPromptDelete                  AuditLogEvent = 165 // Guild Onboarding Question was deleted
+	OnboardingCreate                        AuditLogEvent = 166 // Guild Onboarding was created
+	OnboardingUpdate                        AuditLogEvent = 167 // Guild Onboarding was updated
+	HomeSettingsCreate                      AuditLogEvent = 190 // Guild Server Guide was created
+	HomeSettingsUpdate                      AuditLogEvent = 191 // Guild Server Guide was updated
+)
@@ -0,0 +1,70 @@
+package discord
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord/audit_log_event"
+)
+
+const roleUpdateAuditLog = `{
+	"audit_log_entries": [{
+		"id": "900",
+		"user_id": "5",
+		"target_id": "6",
+		"action_type": 25,
+		"reason": "promotion",
+		"changes": [
+			{"key": "$add", "new_value": [{"id": "20", "name": "Moderator"}]},
+			{"key": "permissions", "old_value": "2048", "new_value": "10240"},
+			{"key": "name", "old_value": "old", "new_value": "new"},
+			{"key": "name", "new_value": 5},
+			{"key": "format_type", "new_value": 1}
+		]
+	}],
+	"users": [{"id": "5", "username": "moderator"}],
+	"threads": [],
+	"webhooks": [],
+	"integrations": [],
+	"application_commands": []
+}`
+
+func TestAuditLogChangeParsing(t *testing.T) {
+	auditLog := AuditLog{}
+	if err := json.Unmarshal([]byte(roleUpdateAuditLog), &auditLog); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := auditLog.AuditLogEntries[0]
+	if entry.ActionType != audit_log_event.MemberRoleUpdate {
+		t.Errorf("Expected MemberRoleUpdate, got %d", entry.ActionType)
+	}
+	if user := auditLog.GetUser(*entry.UserId); user == nil || user.Username != "moderator" {
+		t.Errorf("Expected to find the moderator, got %v", user)
+	}
+
+	_, added := AuditLogChangeValues[[]AuditLogRole](entry.GetChange("$add"))
+	if added == nil || len(*added) != 1 || (*added)[0].Id != 20 {
+		t.Errorf("Expected role 20 to be added, got %v", added)
+	}
+
+	oldPermissions, newPermissions := AuditLogChangeValues[Permissions](entry.GetChange("permissions"))
+	if oldPermissions == nil || *oldPermissions != PermissionSendMessages {
+		t.Errorf("Expected old permissions to be Send Messages, got %v", oldPermissions)
+	}
+	if newPermissions == nil || !newPermissions.Has(PermissionSendMessages|PermissionManageMessages) {
+		t.Errorf("Expected new permissions to add Manage Messages, got %v", newPermissions)
+	}
+
+	if _, name := AuditLogChangeValues[string](entry.GetChange("name")); name == nil || *name != "new" {
+		t.Errorf("Expected the new name, got %v", name)
+	}
+
+	if value, ok := entry.Changes[3].NewValue.(float64); !ok || value != 5 {
+		t.Errorf("Expected a mistyped value to fall back to plain JSON, got %v", entry.Changes[3].NewValue)
+	}
+
+	if value, ok := entry.Changes[4].NewValue.(float64); !ok || value != 1 {
+		t.Errorf("Expected an unknown key to be decoded as plain JSON, got %v", entry.Changes[4].NewValue)
+	}
+}
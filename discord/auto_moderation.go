@@ -0,0 +1,217 @@
+package discord
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/JackHumphries9/dapper-go/discord/auto_moderation_action_type"
+	"github.com/JackHumphries9/dapper-go/discord/auto_moderation_event_type"
+	"github.com/JackHumphries9/dapper-go/discord/auto_moderation_trigger_type"
+	"github.com/JackHumphries9/dapper-go/discord/keyword_preset_type"
+)
+
+const (
+	MaxAutoModerationKeywords            = 1000
+	MaxAutoModerationKeywordLength       = 60
+	MaxAutoModerationRegexPatterns       = 10
+	MaxAutoModerationRegexPatternLength  = 260
+	MaxAutoModerationAllowList           = 100
+	MaxAutoModerationPresetAllowList     = 1000
+	MaxAutoModerationMentionTotalLimit   = 50
+	MaxAutoModerationCustomMessageLength = 150
+	MaxAutoModerationTimeoutSeconds      = 2419200 // 4 weeks
+	MaxAutoModerationExemptRoles         = 20
+	MaxAutoModerationExemptChannels      = 50
+	MaxAutoModerationRuleNameLength      = 100
+)
+
+type AutoModerationRule struct {
+	Id              Snowflake                                              `json:"id"`
+	GuildId         Snowflake                                              `json:"guild_id"`
+	Name            string                                                 `json:"name"`
+	CreatorId       Snowflake                                              `json:"creator_id"`
+	EventType       auto_moderation_event_type.AutoModerationEventType     `json:"event_type"`
+	TriggerType     auto_moderation_trigger_type.AutoModerationTriggerType `json:"trigger_type"`
+	TriggerMetadata AutoModerationTriggerMetadata                          `json:"trigger_metadata"`
+	Actions         []AutoModerationAction                                 `json:"actions"`
+	Enabled         bool                                                   `json:"enabled"`
+	ExemptRoles     []Snowflake                                            `json:"exempt_roles"`
+	ExemptChannels  []Snowflake                                            `json:"exempt_channels"`
+}
+
+// AutoModerationTriggerMetadata configures the rule's trigger, which fields apply depends on the trigger type
+type AutoModerationTriggerMetadata struct {
+	// Keyword and MemberProfile, supports * wildcards
+	KeywordFilter []string `json:"keyword_filter,omitempty"`
+	// Keyword and MemberProfile, Rust flavoured regex
+	RegexPatterns []string `json:"regex_patterns,omitempty"`
+	// KeywordPreset
+	Presets []keyword_preset_type.KeywordPresetType `json:"presets,omitempty"`
+	// Keyword, KeywordPreset and MemberProfile, substrings that won't trigger the rule
+	AllowList []string `json:"allow_list,omitempty"`
+	// MentionSpam, unique role and user mentions allowed per message
+	MentionTotalLimit *int `json:"mention_total_limit,omitempty"`
+	// MentionSpam
+	MentionRaidProtectionEnabled *bool `json:"mention_raid_protection_enabled,omitempty"`
+}
+
+type AutoModerationAction struct {
+	Type     auto_moderation_action_type.AutoModerationActionType `json:"type"`
+	Metadata *AutoModerationActionMetadata                        `json:"metadata,omitempty"`
+}
+
+type AutoModerationActionMetadata struct {
+	// SendAlertMessage
+	ChannelId *Snowflake `json:"channel_id,omitempty"`
+	// Timeout
+	DurationSeconds *int `json:"duration_seconds,omitempty"`
+	// BlockMessage, shown to the member whose message was blocked
+	CustomMessage *string `json:"custom_message,omitempty"`
+}
+
+func (metadata AutoModerationTriggerMetadata) Verify(triggerType auto_moderation_trigger_type.AutoModerationTriggerType) error {
+	usesKeywords := triggerType == auto_moderation_trigger_type.Keyword || triggerType == auto_moderation_trigger_type.MemberProfile
+
+	if len(metadata.KeywordFilter) > 0 && !usesKeywords {
+		return fmt.Errorf("keyword filter can only be used by keyword and member profile rules")
+	}
+	if len(metadata.KeywordFilter) > MaxAutoModerationKeywords {
+		return fmt.Errorf("keyword filter cannot have more than %d keywords (you have %d)", MaxAutoModerationKeywords, len(metadata.KeywordFilter))
+	}
+	if err := verifyAutoModerationStrings("keyword", metadata.KeywordFilter, MaxAutoModerationKeywordLength); err != nil {
+		return err
+	}
+
+	if len(metadata.RegexPatterns) > 0 && !usesKeywords {
+		return fmt.Errorf("regex patterns can only be used by keyword and member profile rules")
+	}
+	if len(metadata.RegexPatterns) > MaxAutoModerationRegexPatterns {
+		return fmt.Errorf("cannot have more than %d regex patterns (you have %d)", MaxAutoModerationRegexPatterns, len(metadata.RegexPatterns))
+	}
+	if err := verifyAutoModerationStrings("regex pattern", metadata.RegexPatterns, MaxAutoModerationRegexPatternLength); err != nil {
+		return err
+	}
+
+	if len(metadata.Presets) > 0 && triggerType != auto_moderation_trigger_type.KeywordPreset {
+		return fmt.Errorf("presets can only be used by keyword preset rules")
+	}
+	for _, preset := range metadata.Presets {
+		if preset < keyword_preset_type.Profanity || preset > keyword_preset_type.Slurs {
+			return fmt.Errorf("unknown keyword preset %d", preset)
+		}
+	}
+
+	maxAllowList := MaxAutoModerationAllowList
+	if triggerType == auto_moderation_trigger_type.KeywordPreset {
+		maxAllowList = MaxAutoModerationPresetAllowList
+	} else if len(metadata.AllowList) > 0 && !usesKeywords {
+		return fmt.Errorf("allow list can only be used by keyword, keyword preset and member profile rules")
+	}
+	if len(metadata.AllowList) > maxAllowList {
+		return fmt.Errorf("allow list cannot have more than %d entries (you have %d)", maxAllowList, len(metadata.AllowList))
+	}
+	if err := verifyAutoModerationStrings("allow list entry", metadata.AllowList, MaxAutoModerationKeywordLength); err != nil {
+		return err
+	}
+
+	if (metadata.MentionTotalLimit != nil || metadata.MentionRaidProtectionEnabled != nil) && triggerType != auto_moderation_trigger_type.MentionSpam {
+		return fmt.Errorf("mention limits can only be used by mention spam rules")
+	}
+	if metadata.MentionTotalLimit != nil && (*metadata.MentionTotalLimit < 0 || *metadata.MentionTotalLimit > MaxAutoModerationMentionTotalLimit) {
+		return fmt.Errorf("mention total limit must be between 0 and %d (you have %d)", MaxAutoModerationMentionTotalLimit, *metadata.MentionTotalLimit)
+	}
+
+	return nil
+}
+
+// VerifyAutoModerationTrigger checks a rule's trigger metadata, including that keyword rules have keywords or
+// regex patterns and keyword preset rules have presets, since those rules can't match anything without them
+func VerifyAutoModerationTrigger(triggerType auto_moderation_trigger_type.AutoModerationTriggerType, metadata *AutoModerationTriggerMetadata) error {
+	if metadata == nil {
+		metadata = &AutoModerationTriggerMetadata{}
+	}
+
+	switch triggerType {
+	case auto_moderation_trigger_type.Keyword:
+		if len(metadata.KeywordFilter) == 0 && len(metadata.RegexPatterns) == 0 {
+			return fmt.Errorf("keyword rules must have a keyword filter or regex patterns")
+		}
+	case auto_moderation_trigger_type.KeywordPreset:
+		if len(metadata.Presets) == 0 {
+			return fmt.Errorf("keyword preset rules must have at least one preset")
+		}
+	}
+
+	return metadata.Verify(triggerType)
+}
+
+func verifyAutoModerationStrings(name string, values []string, maxLength int) error {
+	for i, value := range values {
+		if value == "" {
+			return fmt.Errorf("%s %d cannot be empty", name, i+1)
+		}
+
+		if length := utf8.RuneCountInString(value); length > maxLength {
+			return fmt.Errorf("%s %d cannot be longer than %d characters (you have %d)", name, i+1, maxLength, length)
+		}
+	}
+
+	return nil
+}
+
+func (action AutoModerationAction) Verify(triggerType auto_moderation_trigger_type.AutoModerationTriggerType) error {
+	metadata := action.Metadata
+	if metadata == nil {
+		metadata = &AutoModerationActionMetadata{}
+	}
+
+	if metadata.CustomMessage != nil {
+		if action.Type != auto_moderation_action_type.BlockMessage {
+			return fmt.Errorf("only block message actions can have a custom message")
+		}
+
+		if length := utf8.RuneCountInString(*metadata.CustomMessage); length > MaxAutoModerationCustomMessageLength {
+			return fmt.Errorf("custom message cannot be longer than %d characters (you have %d)", MaxAutoModerationCustomMessageLength, length)
+		}
+	}
+
+	switch action.Type {
+	case auto_moderation_action_type.BlockMessage:
+		if triggerType == auto_moderation_trigger_type.MemberProfile {
+			return fmt.Errorf("member profile rules cannot block messages")
+		}
+	case auto_moderation_action_type.SendAlertMessage:
+		if metadata.ChannelId == nil {
+			return fmt.Errorf("send alert message actions must have a channel")
+		}
+	case auto_moderation_action_type.Timeout:
+		if triggerType != auto_moderation_trigger_type.Keyword && triggerType != auto_moderation_trigger_type.MentionSpam {
+			return fmt.Errorf("timeout actions can only be used by keyword and mention spam rules")
+		}
+
+		if metadata.DurationSeconds == nil || *metadata.DurationSeconds < 0 || *metadata.DurationSeconds > MaxAutoModerationTimeoutSeconds {
+			return fmt.Errorf("timeout actions must have a duration between 0 and %d seconds", MaxAutoModerationTimeoutSeconds)
+		}
+	case auto_moderation_action_type.BlockMemberInteraction:
+		if triggerType != auto_moderation_trigger_type.MemberProfile {
+			return fmt.Errorf("block member interaction actions can only be used by member profile rules")
+		}
+	default:
+		return fmt.Errorf("unknown auto moderation action type %d", action.Type)
+	}
+
+	return nil
+}
+
+// VerifyAutoModerationExemptions checks the number of exempt roles and channels a rule has
+func VerifyAutoModerationExemptions(roles []Snowflake, channels []Snowflake) error {
+	if len(roles) > MaxAutoModerationExemptRoles {
+		return fmt.Errorf("cannot exempt more than %d roles (you have %d)", MaxAutoModerationExemptRoles, len(roles))
+	}
+
+	if len(channels) > MaxAutoModerationExemptChannels {
+		return fmt.Errorf("cannot exempt more than %d channels (you have %d)", MaxAutoModerationExemptChannels, len(channels))
+	}
+
+	return nil
+}
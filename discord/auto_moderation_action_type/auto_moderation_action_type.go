@@ -0,0 +1,10 @@
+package auto_moderation_action_type
+
+type AutoModerationActionType int
+
+const (
+	BlockMessage           AutoModerationActionType = 1 // Blocks a member's message, optionally with a custom explanation
+	SendAlertMessage       AutoModerationActionType = 2 // Logs the user content to a specified channel
+	Timeout                AutoModerationActionType = 3 // Times out the user for a specified duration
+	BlockMemberInteraction AutoModerationActionType = 4 // Prevents the member from using text, voice or other interactions
+)
@@ -0,0 +1,8 @@
+package auto_moderation_event_type
+
+type AutoModerationEventType int
+
+const (
+	MessageSend  AutoModerationEventType = 1 // When a member sends or edits a message in the guild
+	MemberUpdate AutoModerationEventType = 2 // When a member edits their profile
+)
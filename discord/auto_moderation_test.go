@@ -0,0 +1,123 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord/auto_moderation_action_type"
+	"github.com/JackHumphries9/dapper-go/discord/auto_moderation_trigger_type"
+	"github.com/JackHumphries9/dapper-go/discord/keyword_preset_type"
+)
+
+func TestAutoModerationTriggerMetadataVerify(t *testing.T) {
+	mentionLimit := 51
+
+	valid := AutoModerationTriggerMetadata{
+		KeywordFilter: []string{"cat*"},
+		AllowList:     []string{"catalogue"},
+	}
+	if err := valid.Verify(auto_moderation_trigger_type.Keyword); err != nil {
+		t.Errorf("Expected keyword metadata to be valid, got %v", err)
+	}
+
+	cases := []struct {
+		name        string
+		metadata    AutoModerationTriggerMetadata
+		triggerType auto_moderation_trigger_type.AutoModerationTriggerType
+	}{
+		{"keywords on a preset rule", AutoModerationTriggerMetadata{KeywordFilter: []string{"cat"}}, auto_moderation_trigger_type.KeywordPreset},
+		{"long keyword", AutoModerationTriggerMetadata{KeywordFilter: []string{strings.Repeat("a", 61)}}, auto_moderation_trigger_type.Keyword},
+		{"too many regex patterns", AutoModerationTriggerMetadata{RegexPatterns: make([]string, 11)}, auto_moderation_trigger_type.Keyword},
+		{"unknown preset", AutoModerationTriggerMetadata{Presets: []keyword_preset_type.KeywordPresetType{9}}, auto_moderation_trigger_type.KeywordPreset},
+		{"mention limit too high", AutoModerationTriggerMetadata{MentionTotalLimit: &mentionLimit}, auto_moderation_trigger_type.MentionSpam},
+	}
+
+	for _, c := range cases {
+		if err := c.metadata.Verify(c.triggerType); err == nil {
+			t.Errorf("Expected an error for %s", c.name)
+		}
+	}
+
+	presetAllowList := AutoModerationTriggerMetadata{AllowList: make([]string, 500)}
+	for i := range presetAllowList.AllowList {
+		presetAllowList.AllowList[i] = "word"
+	}
+	if err := presetAllowList.Verify(auto_moderation_trigger_type.KeywordPreset); err != nil {
+		t.Errorf("Expected preset rules to allow 1000 allow list entries, got %v", err)
+	}
+}
+
+func TestAutoModerationActionVerify(t *testing.T) {
+	duration := 60
+	customMessage := strings.Repeat("a", 151)
+
+	timeout := AutoModerationAction{
+		Type:     auto_moderation_action_type.Timeout,
+		Metadata: &AutoModerationActionMetadata{DurationSeconds: &duration},
+	}
+	if err := timeout.Verify(auto_moderation_trigger_type.Keyword); err != nil {
+		t.Errorf("Expected timeout to be valid on a keyword rule, got %v", err)
+	}
+	if err := timeout.Verify(auto_moderation_trigger_type.Spam); err == nil {
+		t.Errorf("Expected timeout to be invalid on a spam rule")
+	}
+
+	alert := AutoModerationAction{Type: auto_moderation_action_type.SendAlertMessage}
+	if err := alert.Verify(auto_moderation_trigger_type.Keyword); err == nil {
+		t.Errorf("Expected alert without a channel to be invalid")
+	}
+
+	block := AutoModerationAction{
+		Type:     auto_moderation_action_type.BlockMessage,
+		Metadata: &AutoModerationActionMetadata{CustomMessage: &customMessage},
+	}
+	if err := block.Verify(auto_moderation_trigger_type.Keyword); err == nil {
+		t.Errorf("Expected a long custom message to be invalid")
+	}
+}
+
+func TestVerifyAutoModerationTrigger(t *testing.T) {
+	cases := []struct {
+		name        string
+		triggerType auto_moderation_trigger_type.AutoModerationTriggerType
+		metadata    *AutoModerationTriggerMetadata
+		valid       bool
+	}{
+		{"keyword rule with keywords", auto_moderation_trigger_type.Keyword, &AutoModerationTriggerMetadata{KeywordFilter: []string{"cat"}}, true},
+		{"keyword rule with regex patterns", auto_moderation_trigger_type.Keyword, &AutoModerationTriggerMetadata{RegexPatterns: []string{"c.t"}}, true},
+		{"keyword rule without metadata", auto_moderation_trigger_type.Keyword, nil, false},
+		{"keyword rule with only an allow list", auto_moderation_trigger_type.Keyword, &AutoModerationTriggerMetadata{AllowList: []string{"cat"}}, false},
+		{"preset rule with presets", auto_moderation_trigger_type.KeywordPreset, &AutoModerationTriggerMetadata{Presets: []keyword_preset_type.KeywordPresetType{keyword_preset_type.Slurs}}, true},
+		{"preset rule without presets", auto_moderation_trigger_type.KeywordPreset, &AutoModerationTriggerMetadata{}, false},
+		{"spam rule without metadata", auto_moderation_trigger_type.Spam, nil, true},
+		{"invalid metadata", auto_moderation_trigger_type.Spam, &AutoModerationTriggerMetadata{KeywordFilter: []string{"cat"}}, false},
+	}
+
+	for _, c := range cases {
+		err := VerifyAutoModerationTrigger(c.triggerType, c.metadata)
+		if c.valid && err != nil {
+			t.Errorf("Expected %s to be valid, got %v", c.name, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("Expected an error for %s", c.name)
+		}
+	}
+}
+
+func TestAutoModerationVerifyCountsCharacters(t *testing.T) {
+	// Limits are in characters, not bytes
+	keyword := strings.Repeat("é", MaxAutoModerationKeywordLength)
+	metadata := AutoModerationTriggerMetadata{KeywordFilter: []string{keyword}}
+	if err := metadata.Verify(auto_moderation_trigger_type.Keyword); err != nil {
+		t.Errorf("Expected a %d character keyword to be valid, got %v", MaxAutoModerationKeywordLength, err)
+	}
+
+	customMessage := strings.Repeat("é", MaxAutoModerationCustomMessageLength)
+	block := AutoModerationAction{
+		Type:     auto_moderation_action_type.BlockMessage,
+		Metadata: &AutoModerationActionMetadata{CustomMessage: &customMessage},
+	}
+	if err := block.Verify(auto_moderation_trigger_type.Keyword); err != nil {
+		t.Errorf("Expected a %d character custom message to be valid, got %v", MaxAutoModerationCustomMessageLength, err)
+	}
+}
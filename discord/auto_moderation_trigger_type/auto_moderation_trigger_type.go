@@ -0,0 +1,11 @@
+package auto_moderation_trigger_type
+
+type AutoModerationTriggerType int
+
+const (
+	Keyword       AutoModerationTriggerType = 1 // Checks if content contains words from a user defined list of keywords, max 6 per guild
+	Spam          AutoModerationTriggerType = 3 // Checks if content represents generic spam, max 1 per guild
+	KeywordPreset AutoModerationTriggerType = 4 // Checks if content contains words from internal pre-defined wordsets, max 1 per guild
+	MentionSpam   AutoModerationTriggerType = 5 // Checks if content contains more unique mentions than allowed, max 1 per guild
+	MemberProfile AutoModerationTriggerType = 6 // Checks if member profile contains words from a user defined list of keywords, max 1 per guild
+)
@@ -0,0 +1,6 @@
+package discord
+
+type Ban struct {
+	Reason *string `json:"reason"`
+	User   User    `json:"user"`
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/JackHumphries9/dapper-go/discord/channel_type"
+	"github.com/JackHumphries9/dapper-go/discord/overwrite_type"
 )
 
 type Channel struct {
@@ -46,10 +47,10 @@ type Channel struct {
 
 type Overwrite struct {
 	// Role or User ID
-	Id    Snowflake   `json:"id"`
-	Type  string      `json:"type"`
-	Allow Permissions `json:"allow"`
-	Deny  Permissions `json:"deny"`
+	Id    Snowflake                    `json:"id"`
+	Type  overwrite_type.OverwriteType `json:"type"`
+	Allow Permissions                  `json:"allow"`
+	Deny  Permissions                  `json:"deny"`
 }
 
 type ThreadMetadata struct {
@@ -1,6 +1,9 @@
 package discord
 
-import "github.com/JackHumphries9/dapper-go/discord/command_type"
+import (
+	"github.com/JackHumphries9/dapper-go/discord/command_option_type"
+	"github.com/JackHumphries9/dapper-go/discord/command_type"
+)
 
 type ApplicationCommandData struct {
 	Id        Snowflake                           `json:"id"`
@@ -31,3 +34,51 @@ func (commandData *ApplicationCommandData) GetOption(optionName string) *Applica
 	}
 	return &option
 }
+
+// FindOption looks for an option at any level, descending into the invoked subcommand group and subcommand
+func (commandData *ApplicationCommandData) FindOption(optionName string) *ApplicationCommandDataOption {
+	return findOption(commandData.Options, optionName)
+}
+
+// SubcommandPath returns the names of the invoked subcommand group and subcommand, if any
+func (commandData *ApplicationCommandData) SubcommandPath() []string {
+	path := make([]string, 0, 2)
+
+	options := commandData.Options
+	for {
+		subcommand := findSubcommand(options)
+		if subcommand == nil {
+			return path
+		}
+
+		path = append(path, subcommand.Name)
+		options = subcommand.Options
+	}
+}
+
+func findOption(options []ApplicationCommandDataOption, optionName string) *ApplicationCommandDataOption {
+	for {
+		for i := range options {
+			if options[i].Name == optionName {
+				return &options[i]
+			}
+		}
+
+		subcommand := findSubcommand(options)
+		if subcommand == nil {
+			return nil
+		}
+
+		options = subcommand.Options
+	}
+}
+
+func findSubcommand(options []ApplicationCommandDataOption) *ApplicationCommandDataOption {
+	for i := range options {
+		if options[i].Type == command_option_type.SubCommand || options[i].Type == command_option_type.SubCommandGroup {
+			return &options[i]
+		}
+	}
+
+	return nil
+}
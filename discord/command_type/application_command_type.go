@@ -1,9 +1,10 @@
 package command_type
 
 const (
-	ChatInput ApplicationCommandType = iota + 1 // (slash-command)
-	User
-	Message
+	ChatInput         ApplicationCommandType = iota + 1 // (slash-command)
+	User                                                // (user context menu)
+	Message                                             // (message context menu)
+	PrimaryEntryPoint                                   // (launches an Activity from the App Launcher)
 )
 
 type ApplicationCommandType uint8
@@ -1,14 +1,23 @@
 package component_type
 
 const (
-	ActionRow ComponentType = iota + 1
-	Button
-	StringSelect
-	TextInput
-	UserSelect
-	RoleSelect
-	MentionableSelect
-	ChannelSelect
+	ActionRow         ComponentType = 1  // Container for other components
+	Button            ComponentType = 2  // Button object
+	StringSelect      ComponentType = 3  // Select menu for picking from defined text options
+	TextInput         ComponentType = 4  // Text input object
+	UserSelect        ComponentType = 5  // Select menu for users
+	RoleSelect        ComponentType = 6  // Select menu for roles
+	MentionableSelect ComponentType = 7  // Select menu for mentionables (users and roles)
+	ChannelSelect     ComponentType = 8  // Select menu for channels
+	Section           ComponentType = 9  // Container to display text alongside an accessory component
+	TextDisplay       ComponentType = 10 // Markdown text
+	Thumbnail         ComponentType = 11 // Small image that can be used as an accessory
+	MediaGallery      ComponentType = 12 // Display images and other media
+	File              ComponentType = 13 // Displays an attached file
+	Separator         ComponentType = 14 // Component to add vertical padding between other components
+	Container         ComponentType = 17 // Container that visually groups a set of components
+	Label             ComponentType = 18 // Container associating a label and description with a component
+	FileUpload        ComponentType = 19 // Component for uploading files in modals
 )
 
 type ComponentType uint8
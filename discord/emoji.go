@@ -1,5 +1,7 @@
 package discord
 
+import "net/url"
+
 type Emoji struct {
 	Id            *Snowflake  `json:"id,omitempty"`
 	Name          *string     `json:"name,omitempty"`
@@ -26,3 +28,16 @@ func (e Emoji) String() string {
 
 	return "<:" + emojiName + ":" + e.Id.String() + ">"
 }
+
+// URLEncoded formats the emoji for reaction endpoints, the unicode character for standard emoji or name:id for custom emoji
+func (e Emoji) URLEncoded() string {
+	emojiName := ""
+	if e.Name != nil {
+		emojiName = *e.Name
+	}
+	if e.Id == nil {
+		return url.PathEscape(emojiName)
+	}
+
+	return url.PathEscape(emojiName + ":" + e.Id.String())
+}
@@ -0,0 +1,39 @@
+package discord
+
+import "testing"
+
+func TestEmoji_URLEncoded(t *testing.T) {
+	thumbsUp := "👍"
+	custom := "blobcat"
+	id := Snowflake(123)
+
+	tests := []struct {
+		name  string
+		emoji Emoji
+		want  string
+	}{
+		{"unicode", Emoji{Name: &thumbsUp}, "%F0%9F%91%8D"},
+		{"custom", Emoji{Name: &custom, Id: &id}, "blobcat:123"},
+		{"custom without name", Emoji{Id: &id}, ":123"},
+		{"empty", Emoji{}, ""},
+	}
+
+	for _, test := range tests {
+		if got := test.emoji.URLEncoded(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestEmoji_String(t *testing.T) {
+	custom := "blobcat"
+	id := Snowflake(123)
+	animated := true
+
+	if got := (Emoji{Name: &custom, Id: &id}).String(); got != "<:blobcat:123>" {
+		t.Errorf("Unexpected custom emoji mention %q", got)
+	}
+	if got := (Emoji{Name: &custom, Id: &id, Animated: &animated}).String(); got != "<a:blobcat:123>" {
+		t.Errorf("Unexpected animated emoji mention %q", got)
+	}
+}
@@ -0,0 +1,8 @@
+package entitlement_owner_type
+
+type EntitlementOwnerType int
+
+const (
+	Guild EntitlementOwnerType = iota + 1 // 1 - Entitlement is owned by a guild
+	User                                  // 2 - Entitlement is owned by a user
+)
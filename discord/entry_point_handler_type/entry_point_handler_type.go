@@ -0,0 +1,8 @@
+package entry_point_handler_type
+
+type EntryPointCommandHandlerType int
+
+const (
+	AppHandler            EntryPointCommandHandlerType = iota + 1 // 1 - The app handles the interaction using an interaction token
+	DiscordLaunchActivity                                         // 2 - Discord handles the interaction by launching an Activity and sending a follow-up message
+)
@@ -0,0 +1,8 @@
+package integration_type
+
+type IntegrationType int
+
+const (
+	GuildInstall IntegrationType = iota // 0 - App is installable to servers
+	UserInstall                         // 1 - App is installable to users
+)
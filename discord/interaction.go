@@ -8,32 +8,35 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/JackHumphries9/dapper-go/discord/integration_type"
 	"github.com/JackHumphries9/dapper-go/discord/interaction_callback_type"
 	"github.com/JackHumphries9/dapper-go/discord/interaction_context_type"
 	"github.com/JackHumphries9/dapper-go/discord/interaction_type"
 )
 
 type Interaction struct {
-	Id             Snowflake                                        `json:"id"`
-	ApplicationId  Snowflake                                        `json:"application_id"`
-	Type           interaction_type.InteractionType                 `json:"type"`
-	DataInternal   *json.RawMessage                                 `json:"data,omitempty"`
-	Data           InteractionData                                  `json:"-"`
-	GuildId        *Snowflake                                       `json:"guild_id,omitempty"`
-	Guild          *Guild                                           `json:"guild,omitempty"`
-	Channel        *Channel                                         `json:"channel,omitempty"`
-	ChannelId      *Snowflake                                       `json:"channel_id,omitempty"`
-	Member         *Member                                          `json:"member,omitempty"`
-	User           *User                                            `json:"user,omitempty"`
-	Token          string                                           `json:"token"`
-	Version        int                                              `json:"version"`
-	Message        *Message                                         `json:"message,omitempty"`
-	AppPermissions *Permissions                                     `json:"permissions,omitempty"`
-	Locale         string                                           `json:"locale"`
-	GuildLocale    string                                           `json:"guild_locale"`
-	hook           *Webhook                                         // Used for responding to the interaction
-	Context        *interaction_context_type.InteractionContextType `json:"context,omitempty"`
-	Entitlements   []Entitlement                                    `json:"entitlements"`
+	Id                           Snowflake                                        `json:"id"`
+	ApplicationId                Snowflake                                        `json:"application_id"`
+	Type                         interaction_type.InteractionType                 `json:"type"`
+	DataInternal                 *json.RawMessage                                 `json:"data,omitempty"`
+	Data                         InteractionData                                  `json:"-"`
+	GuildId                      *Snowflake                                       `json:"guild_id,omitempty"`
+	Guild                        *Guild                                           `json:"guild,omitempty"`
+	Channel                      *Channel                                         `json:"channel,omitempty"`
+	ChannelId                    *Snowflake                                       `json:"channel_id,omitempty"`
+	Member                       *Member                                          `json:"member,omitempty"`
+	User                         *User                                            `json:"user,omitempty"`
+	Token                        string                                           `json:"token"`
+	Version                      int                                              `json:"version"`
+	Message                      *Message                                         `json:"message,omitempty"`
+	AppPermissions               *Permissions                                     `json:"app_permissions,omitempty"`
+	Locale                       string                                           `json:"locale"`
+	GuildLocale                  string                                           `json:"guild_locale"`
+	hook                         *Webhook                                         // Used for responding to the interaction
+	Context                      *interaction_context_type.InteractionContextType `json:"context,omitempty"`
+	Entitlements                 []Entitlement                                    `json:"entitlements"`
+	AuthorizingIntegrationOwners map[integration_type.IntegrationType]Snowflake   `json:"authorizing_integration_owners,omitempty"` // Guild id ("0" outside a guild) or user id per installation
+	AttachmentSizeLimit          int                                              `json:"attachment_size_limit"`
 }
 
 type InteractionData any
@@ -114,38 +117,73 @@ func (interaction *Interaction) CreateResponse(response InteractionResponse) err
 }
 
 func (interaction *Interaction) CreateResponseWithContext(ctx context.Context, response InteractionResponse) error {
+	_, err := interaction.sendResponse(ctx, response, false)
+	return err
+}
+
+// CreateResponseWithResponse responds to the interaction and returns the created callback, including the
+// message or activity instance it created
+func (interaction *Interaction) CreateResponseWithResponse(ctx context.Context, response InteractionResponse) (*InteractionCallbackResponse, error) {
+	body, err := interaction.sendResponse(ctx, response, true)
+	if err != nil {
+		return nil, err
+	}
+
+	callbackResponse := &InteractionCallbackResponse{}
+	if err := json.Unmarshal(body, callbackResponse); err != nil {
+		return nil, fmt.Errorf("error unmarshalling response: %w", err)
+	}
+
+	return callbackResponse, nil
+}
+
+func (interaction *Interaction) sendResponse(ctx context.Context, response InteractionResponse, withResponse bool) ([]byte, error) {
 	data, err := json.Marshal(response)
 	if err != nil {
-		return fmt.Errorf("error marshaling data to JSON: %w", err)
+		return nil, fmt.Errorf("error marshaling data to JSON: %w", err)
+	}
+
+	url := fmt.Sprintf(createInteractionResponseUrl, interaction.Id, interaction.Token)
+	expectedStatus := 204
+	if withResponse {
+		url += "?with_response=true"
+		expectedStatus = 200
 	}
+
 	var request *http.Request
 	if ctx != nil {
-		request, err = http.NewRequestWithContext(ctx, "POST", fmt.Sprintf(createInteractionResponseUrl, interaction.Id, interaction.Token), bytes.NewReader(data))
+		request, err = http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(data))
 	} else {
-		request, err = http.NewRequest("POST", fmt.Sprintf(createInteractionResponseUrl, interaction.Id, interaction.Token), bytes.NewReader(data))
+		request, err = http.NewRequest("POST", url, bytes.NewReader(data))
 	}
 	if err != nil {
-		return fmt.Errorf("error creating HTTP request: %w", err)
+		return nil, fmt.Errorf("error creating HTTP request: %w", err)
 	}
 
 	request.Header.Set("Content-Type", "application/json")
 
 	resp, err := httpClient.Do(request)
 	if err != nil {
-		return fmt.Errorf("error sending HTTP request: %w", err)
+		return nil, fmt.Errorf("error sending HTTP request: %w", err)
 	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
 
-	if resp.StatusCode != 204 {
-		responseBody, err := io.ReadAll(resp.Body)
+	if resp.StatusCode != expectedStatus {
 		if err != nil {
-			return fmt.Errorf("expected status code 204, got %d", resp.StatusCode)
+			return nil, fmt.Errorf("expected status code %d, got %d", expectedStatus, resp.StatusCode)
 		}
-		return fmt.Errorf(
-			"error sending interaction response, status code %d (expected 204)\nresponse body: %s\nrequest body: %s",
-			resp.StatusCode, string(responseBody), string(data))
+		return nil, fmt.Errorf(
+			"error sending interaction response, status code %d (expected %d)\nresponse body: %s\nrequest body: %s",
+			resp.StatusCode, expectedStatus, string(responseBody), string(data))
 	}
 
-	return nil
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	return responseBody, nil
 }
 
 func (interaction *Interaction) GetWebhook() *Webhook {
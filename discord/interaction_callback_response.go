@@ -0,0 +1,31 @@
+package discord
+
+import (
+	"github.com/JackHumphries9/dapper-go/discord/interaction_callback_type"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_type"
+)
+
+// InteractionCallbackResponse is returned when responding to an interaction with with_response=true
+type InteractionCallbackResponse struct {
+	Interaction InteractionCallback          `json:"interaction"`
+	Resource    *InteractionCallbackResource `json:"resource,omitempty"`
+}
+
+type InteractionCallback struct {
+	Id                       Snowflake                        `json:"id"`
+	Type                     interaction_type.InteractionType `json:"type"`
+	ActivityInstanceId       *string                          `json:"activity_instance_id,omitempty"`
+	ResponseMessageId        *Snowflake                       `json:"response_message_id,omitempty"`
+	ResponseMessageLoading   *bool                            `json:"response_message_loading,omitempty"`
+	ResponseMessageEphemeral *bool                            `json:"response_message_ephemeral,omitempty"`
+}
+
+type InteractionCallbackResource struct {
+	Type             interaction_callback_type.InteractionCallbackType `json:"type"`
+	ActivityInstance *ActivityInstance                                 `json:"activity_instance,omitempty"`
+	Message          *Message                                          `json:"message,omitempty"` // Only for ChannelMessageWithSource and UpdateMessage
+}
+
+type ActivityInstance struct {
+	Id string `json:"id"`
+}
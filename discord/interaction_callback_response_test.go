@@ -0,0 +1,124 @@
+package discord
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord/interaction_callback_type"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_type"
+)
+
+type roundTripFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// withFakeCallback swaps the package http client for one that records the callback url and answers with status and body
+func withFakeCallback(t *testing.T, status int, body string) *string {
+	t.Helper()
+
+	previous := httpClient
+	t.Cleanup(func() { SetHttpClient(previous) })
+
+	url := new(string)
+	SetHttpClient(http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		*url = request.URL.String()
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}, nil
+	})})
+
+	return url
+}
+
+func TestInteraction_CreateResponseWithResponse(t *testing.T) {
+	url := withFakeCallback(t, 200, `{
+		"interaction": {"id": "1", "type": 2, "response_message_id": "50", "response_message_loading": false},
+		"resource": {"type": 4, "message": {"id": "50", "content": "pong"}}
+	}`)
+
+	content := "pong"
+	interaction := &Interaction{Id: 1, Token: "t"}
+	callback, err := interaction.CreateResponseWithResponse(context.Background(), InteractionResponse{
+		Type: interaction_callback_type.ChannelMessageWithSource,
+		Data: &MessageCallbackData{Content: &content},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if *url != "https://discord.com/api/interactions/1/t/callback?with_response=true" {
+		t.Errorf("Unexpected callback url %q", *url)
+	}
+
+	if callback.Interaction.Type != interaction_type.ApplicationCommand {
+		t.Errorf("Expected an application command interaction, got %v", callback.Interaction.Type)
+	}
+	if callback.Interaction.ResponseMessageId == nil || *callback.Interaction.ResponseMessageId != 50 {
+		t.Errorf("Expected response message id 50, got %v", callback.Interaction.ResponseMessageId)
+	}
+	if callback.Resource == nil || callback.Resource.Type != interaction_callback_type.ChannelMessageWithSource {
+		t.Fatalf("Expected a channel message resource, got %+v", callback.Resource)
+	}
+	if callback.Resource.Message == nil || callback.Resource.Message.Content != "pong" {
+		t.Errorf("Expected the created message in the resource, got %+v", callback.Resource.Message)
+	}
+}
+
+func TestInteraction_CreateResponseWithResponseActivity(t *testing.T) {
+	withFakeCallback(t, 200, `{
+		"interaction": {"id": "1", "type": 2, "activity_instance_id": "i-1"},
+		"resource": {"type": 12, "activity_instance": {"id": "i-1"}}
+	}`)
+
+	interaction := &Interaction{Id: 1, Token: "t"}
+	callback, err := interaction.CreateResponseWithResponse(context.Background(), InteractionResponse{
+		Type: interaction_callback_type.LaunchActivity,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if callback.Resource == nil || callback.Resource.ActivityInstance == nil || callback.Resource.ActivityInstance.Id != "i-1" {
+		t.Errorf("Expected the launched activity instance, got %+v", callback.Resource)
+	}
+	if callback.Interaction.ActivityInstanceId == nil || *callback.Interaction.ActivityInstanceId != "i-1" {
+		t.Errorf("Expected the activity instance id on the interaction, got %v", callback.Interaction.ActivityInstanceId)
+	}
+}
+
+func TestInteraction_CreateResponseWithResponseStatus(t *testing.T) {
+	// Without with_response Discord answers 204, which is an error when a body was asked for
+	withFakeCallback(t, 204, "")
+
+	interaction := &Interaction{Id: 1, Token: "t"}
+	if _, err := interaction.CreateResponseWithResponse(context.Background(), InteractionResponse{
+		Type: interaction_callback_type.DeferredChannelMessageWithSource,
+	}); err == nil {
+		t.Error("Expected a 204 to be rejected when asking for the response")
+	}
+}
+
+func TestInteraction_CreateResponse(t *testing.T) {
+	url := withFakeCallback(t, 204, "")
+
+	interaction := &Interaction{Id: 1, Token: "t"}
+	if err := interaction.CreateResponse(InteractionResponse{Type: interaction_callback_type.DeferredUpdateMessage}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if *url != "https://discord.com/api/interactions/1/t/callback" {
+		t.Errorf("Unexpected callback url %q", *url)
+	}
+
+	withFakeCallback(t, 400, `{"message": "Invalid Form Body", "code": 50035}`)
+	err := interaction.CreateResponse(InteractionResponse{Type: interaction_callback_type.DeferredUpdateMessage})
+	if err == nil || !strings.Contains(err.Error(), "expected 204") {
+		t.Errorf("Expected a status error mentioning 204, got %v", err)
+	}
+}
@@ -1,13 +1,15 @@
 package interaction_callback_type
 
 const (
-	Pong                     InteractionCallbackType = iota + 1
-	ChannelMessageWithSource                         = iota + 3
-	DeferredChannelMessageWithSource
-	DeferredUpdateMessage
-	UpdateMessage
-	ApplicationCommandAutocompleteResult
-	Modal
+	Pong                                 InteractionCallbackType = 1  // ACK a Ping
+	ChannelMessageWithSource             InteractionCallbackType = 4  // Respond to an interaction with a message
+	DeferredChannelMessageWithSource     InteractionCallbackType = 5  // ACK an interaction and edit a response later, the user sees a loading state
+	DeferredUpdateMessage                InteractionCallbackType = 6  // For components, ACK an interaction and edit the original message later
+	UpdateMessage                        InteractionCallbackType = 7  // For components, edit the message the component was attached to
+	ApplicationCommandAutocompleteResult InteractionCallbackType = 8  // Respond to an autocomplete interaction with suggested choices
+	Modal                                InteractionCallbackType = 9  // Respond to an interaction with a popup modal
+	PremiumRequired                      InteractionCallbackType = 10 // Deprecated: respond with a premium button instead, see button_style.Premium
+	LaunchActivity                       InteractionCallbackType = 12 // Launch the Activity associated with the app, only for apps with Activities enabled
 )
 
 type InteractionCallbackType uint8
@@ -0,0 +1,45 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord/integration_type"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_context_type"
+)
+
+const userInstallInteraction = `{
+	"id": "1",
+	"application_id": "2",
+	"type": 2,
+	"token": "t",
+	"context": 2,
+	"user": {"id": "5"},
+	"app_permissions": "2048",
+	"authorizing_integration_owners": {"0": "0", "1": "5"},
+	"attachment_size_limit": 10485760,
+	"data": {"id": "3", "name": "ping", "type": 1}
+}`
+
+func TestParseInteractionIntegrationOwners(t *testing.T) {
+	interaction, err := ParseInteraction(userInstallInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owners := interaction.AuthorizingIntegrationOwners
+	if len(owners) != 2 || owners[integration_type.GuildInstall] != 0 || owners[integration_type.UserInstall] != 5 {
+		t.Errorf("Unexpected authorizing integration owners %v", owners)
+	}
+
+	if interaction.Context == nil || *interaction.Context != interaction_context_type.PRIVATE_CHANNEL {
+		t.Errorf("Expected a private channel context, got %v", interaction.Context)
+	}
+
+	if interaction.AppPermissions == nil || *interaction.AppPermissions != PermissionSendMessages {
+		t.Errorf("Expected app permissions to be Send Messages, got %v", interaction.AppPermissions)
+	}
+
+	if interaction.AttachmentSizeLimit != 10485760 {
+		t.Errorf("Expected a 10 MiB attachment size limit, got %d", interaction.AttachmentSizeLimit)
+	}
+}
@@ -0,0 +1,9 @@
+package keyword_preset_type
+
+type KeywordPresetType int
+
+const (
+	Profanity     KeywordPresetType = 1 // Words that may be considered forms of swearing or cursing
+	SexualContent KeywordPresetType = 2 // Words that refer to sexually explicit behavior or activity
+	Slurs         KeywordPresetType = 3 // Personal insults or words that may be considered hate speech
+)
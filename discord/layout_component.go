@@ -0,0 +1,533 @@
+package discord
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JackHumphries9/dapper-go/discord/component_type"
+	"github.com/JackHumphries9/dapper-go/discord/separator_spacing"
+)
+
+// Layout components require the message_flags.IsComponentsV2 flag, which stops the message from using content or embeds
+
+const attachmentRefPrefix = "attachment://"
+
+// UnfurledMediaItem references media by url, use attachment://<filename> for files uploaded with the message
+type UnfurledMediaItem struct {
+	Url          string     `json:"url"`
+	ProxyUrl     *string    `json:"proxy_url,omitempty"`
+	Height       *int       `json:"height,omitempty"`
+	Width        *int       `json:"width,omitempty"`
+	ContentType  *string    `json:"content_type,omitempty"`
+	AttachmentId *Snowflake `json:"attachment_id,omitempty"`
+}
+
+func (media UnfurledMediaItem) IsAttachmentRef() bool {
+	return strings.HasPrefix(media.Url, attachmentRefPrefix)
+}
+
+type Section struct {
+	SectionType component_type.ComponentType `json:"type"`
+	Id          *int                         `json:"id,omitempty"`
+	Components  []MessageComponent           `json:"components"`
+	Accessory   MessageComponent             `json:"accessory"`
+}
+
+func (s Section) MarshalJSON() ([]byte, error) {
+	type Alias Section
+
+	var inner Alias
+	inner = Alias(s)
+	inner.SectionType = component_type.Section
+
+	return json.Marshal(inner)
+}
+
+func (s *Section) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Id         *int              `json:"id"`
+		Components []json.RawMessage `json:"components"`
+		Accessory  json.RawMessage   `json:"accessory"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	s.SectionType = component_type.Section
+	s.Id = raw.Id
+
+	s.Components, err = unmarshalComponents(raw.Components)
+	if err != nil {
+		return err
+	}
+
+	if len(raw.Accessory) > 0 {
+		s.Accessory, err = unmarshalComponent(raw.Accessory)
+	}
+
+	return err
+}
+
+func (s *Section) Type() component_type.ComponentType {
+	return component_type.Section
+}
+
+func (s *Section) Verify() error {
+	if len(s.Components) < 1 || len(s.Components) > 3 {
+		return ErrInvalidPropertyLength{
+			Component:      s,
+			PropertyName:   "components",
+			MaxLength:      3,
+			MinLength:      1,
+			PropertyLength: len(s.Components),
+			PropertyValue:  s.Components,
+		}
+	}
+
+	for _, component := range s.Components {
+		if component == nil {
+			return ErrComponentMissingProperty{s, "components"}
+		}
+		if component.Type() != component_type.TextDisplay {
+			return ErrInvalidChildComponent{s, component}
+		}
+
+		if err := component.Verify(); err != nil {
+			return err
+		}
+	}
+
+	if s.Accessory == nil {
+		return ErrComponentMissingProperty{s, "accessory"}
+	}
+
+	if s.Accessory.Type() != component_type.Thumbnail && s.Accessory.Type() != component_type.Button {
+		return ErrInvalidChildComponent{s, s.Accessory}
+	}
+
+	return s.Accessory.Verify()
+}
+
+type TextDisplay struct {
+	TextDisplayType component_type.ComponentType `json:"type"`
+	Id              *int                         `json:"id,omitempty"`
+	Content         string                       `json:"content"`
+}
+
+func (t TextDisplay) MarshalJSON() ([]byte, error) {
+	type Alias TextDisplay
+
+	var inner Alias
+	inner = Alias(t)
+	inner.TextDisplayType = component_type.TextDisplay
+
+	return json.Marshal(inner)
+}
+
+func (t *TextDisplay) Type() component_type.ComponentType {
+	return component_type.TextDisplay
+}
+
+func (t *TextDisplay) Verify() error {
+	if t.Content == "" {
+		return ErrComponentMissingProperty{t, "content"}
+	}
+
+	return nil
+}
+
+type Thumbnail struct {
+	ThumbnailType component_type.ComponentType `json:"type"`
+	Id            *int                         `json:"id,omitempty"`
+	Media         UnfurledMediaItem            `json:"media"`
+	Description   *string                      `json:"description,omitempty"`
+	Spoiler       bool                         `json:"spoiler,omitempty"`
+}
+
+func (t Thumbnail) MarshalJSON() ([]byte, error) {
+	type Alias Thumbnail
+
+	var inner Alias
+	inner = Alias(t)
+	inner.ThumbnailType = component_type.Thumbnail
+
+	return json.Marshal(inner)
+}
+
+func (t *Thumbnail) Type() component_type.ComponentType {
+	return component_type.Thumbnail
+}
+
+func (t *Thumbnail) Verify() error {
+	if t.Media.Url == "" {
+		return ErrComponentMissingProperty{t, "media.url"}
+	}
+
+	return verifyMediaDescription(t, t.Description)
+}
+
+type MediaGalleryItem struct {
+	Media       UnfurledMediaItem `json:"media"`
+	Description *string           `json:"description,omitempty"`
+	Spoiler     bool              `json:"spoiler,omitempty"`
+}
+
+type MediaGallery struct {
+	MediaGalleryType component_type.ComponentType `json:"type"`
+	Id               *int                         `json:"id,omitempty"`
+	Items            []MediaGalleryItem           `json:"items"`
+}
+
+func (m MediaGallery) MarshalJSON() ([]byte, error) {
+	type Alias MediaGallery
+
+	var inner Alias
+	inner = Alias(m)
+	inner.MediaGalleryType = component_type.MediaGallery
+
+	return json.Marshal(inner)
+}
+
+func (m *MediaGallery) Type() component_type.ComponentType {
+	return component_type.MediaGallery
+}
+
+func (m *MediaGallery) Verify() error {
+	if len(m.Items) < 1 || len(m.Items) > 10 {
+		return ErrInvalidPropertyLength{
+			Component:      m,
+			PropertyName:   "items",
+			MaxLength:      10,
+			MinLength:      1,
+			PropertyLength: len(m.Items),
+			PropertyValue:  m.Items,
+		}
+	}
+
+	for _, item := range m.Items {
+		if item.Media.Url == "" {
+			return ErrComponentMissingProperty{m, "items.media.url"}
+		}
+
+		if err := verifyMediaDescription(m, item.Description); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// File displays an uploaded file, the media must be an attachment://<filename> reference
+type File struct {
+	FileType component_type.ComponentType `json:"type"`
+	Id       *int                         `json:"id,omitempty"`
+	File     UnfurledMediaItem            `json:"file"`
+	Spoiler  bool                         `json:"spoiler,omitempty"`
+	Name     *string                      `json:"name,omitempty"` // Set by Discord
+	Size     *int                         `json:"size,omitempty"` // Set by Discord
+}
+
+func (f File) MarshalJSON() ([]byte, error) {
+	type Alias File
+
+	var inner Alias
+	inner = Alias(f)
+	inner.FileType = component_type.File
+
+	return json.Marshal(inner)
+}
+
+func (f *File) Type() component_type.ComponentType {
+	return component_type.File
+}
+
+func (f *File) Verify() error {
+	if f.File.Url == "" {
+		return ErrComponentMissingProperty{f, "file.url"}
+	}
+
+	if !f.File.IsAttachmentRef() {
+		return ErrFileMustReferenceAttachment{f}
+	}
+
+	return nil
+}
+
+type Separator struct {
+	SeparatorType component_type.ComponentType        `json:"type"`
+	Id            *int                                `json:"id,omitempty"`
+	Divider       *bool                               `json:"divider,omitempty"`
+	Spacing       *separator_spacing.SeparatorSpacing `json:"spacing,omitempty"`
+}
+
+func (s Separator) MarshalJSON() ([]byte, error) {
+	type Alias Separator
+
+	var inner Alias
+	inner = Alias(s)
+	inner.SeparatorType = component_type.Separator
+
+	return json.Marshal(inner)
+}
+
+func (s *Separator) Type() component_type.ComponentType {
+	return component_type.Separator
+}
+
+func (s *Separator) Verify() error {
+	if s.Spacing != nil && *s.Spacing != separator_spacing.Small && *s.Spacing != separator_spacing.Large {
+		return ErrInvalidPropertyValue{s, "spacing", *s.Spacing}
+	}
+
+	return nil
+}
+
+type Container struct {
+	ContainerType component_type.ComponentType `json:"type"`
+	Id            *int                         `json:"id,omitempty"`
+	Components    []MessageComponent           `json:"components"`
+	AccentColor   *int                         `json:"accent_color,omitempty"`
+	Spoiler       bool                         `json:"spoiler,omitempty"`
+}
+
+func (c Container) MarshalJSON() ([]byte, error) {
+	type Alias Container
+
+	var inner Alias
+	inner = Alias(c)
+	inner.ContainerType = component_type.Container
+
+	return json.Marshal(inner)
+}
+
+func (c *Container) UnmarshalJSON(data []byte) error {
+	type Alias Container
+
+	var raw struct {
+		Alias
+		Components []json.RawMessage `json:"components"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*c = Container(raw.Alias)
+	c.ContainerType = component_type.Container
+
+	c.Components, err = unmarshalComponents(raw.Components)
+
+	return err
+}
+
+func (c *Container) Type() component_type.ComponentType {
+	return component_type.Container
+}
+
+func (c *Container) Verify() error {
+	if len(c.Components) == 0 {
+		return ErrComponentMissingProperty{c, "components"}
+	}
+
+	for _, component := range c.Components {
+		if component == nil {
+			return ErrComponentMissingProperty{c, "components"}
+		}
+		if _, ok := component.(*UnknownComponent); ok {
+			continue
+		}
+
+		switch component.Type() {
+		case component_type.ActionRow, component_type.TextDisplay, component_type.Section,
+			component_type.MediaGallery, component_type.Separator, component_type.File:
+		default:
+			return ErrInvalidChildComponent{c, component}
+		}
+
+		if err := component.Verify(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Label wraps a modal component with a label and description, a wrapped text input must not set its own label
+type Label struct {
+	LabelType   component_type.ComponentType `json:"type"`
+	Id          *int                         `json:"id,omitempty"`
+	Label       string                       `json:"label"`
+	Description *string                      `json:"description,omitempty"`
+	Component   MessageComponent             `json:"component"`
+}
+
+func (l Label) MarshalJSON() ([]byte, error) {
+	type Alias Label
+
+	var inner Alias
+	inner = Alias(l)
+	inner.LabelType = component_type.Label
+
+	return json.Marshal(inner)
+}
+
+func (l *Label) UnmarshalJSON(data []byte) error {
+	type Alias Label
+
+	var raw struct {
+		Alias
+		Component json.RawMessage `json:"component"`
+	}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*l = Label(raw.Alias)
+	l.LabelType = component_type.Label
+
+	if len(raw.Component) > 0 {
+		l.Component, err = unmarshalComponent(raw.Component)
+	}
+
+	return err
+}
+
+func (l *Label) Type() component_type.ComponentType {
+	return component_type.Label
+}
+
+func (l *Label) Verify() error {
+	if l.Label == "" {
+		return ErrComponentMissingProperty{l, "label"}
+	}
+	if labelLength := utf8.RuneCountInString(l.Label); labelLength > 45 {
+		return ErrInvalidPropertyLength{
+			Component:      l,
+			PropertyName:   "label",
+			MaxLength:      45,
+			MinLength:      1,
+			PropertyLength: labelLength,
+			PropertyValue:  l.Label,
+		}
+	}
+	if l.Description != nil && utf8.RuneCountInString(*l.Description) > 100 {
+		return ErrInvalidPropertyLength{
+			Component:      l,
+			PropertyName:   "description",
+			MaxLength:      100,
+			MinLength:      0,
+			PropertyLength: utf8.RuneCountInString(*l.Description),
+			PropertyValue:  *l.Description,
+		}
+	}
+
+	if l.Component == nil {
+		return ErrComponentMissingProperty{l, "component"}
+	}
+
+	switch l.Component.Type() {
+	case component_type.TextInput:
+		textInput, ok := l.Component.(*TextInput)
+		if !ok || textInput.Label != "" {
+			return ErrInvalidChildComponent{l, l.Component}
+		}
+
+		return textInput.verifyInput()
+	case component_type.StringSelect, component_type.UserSelect, component_type.RoleSelect,
+		component_type.MentionableSelect, component_type.ChannelSelect, component_type.FileUpload:
+	default:
+		return ErrInvalidChildComponent{l, l.Component}
+	}
+
+	return l.Component.Verify()
+}
+
+// UnknownComponent holds a component type this library doesn't model yet, it is sent back unchanged
+type UnknownComponent struct {
+	ComponentType component_type.ComponentType
+	Raw           json.RawMessage
+}
+
+func (u UnknownComponent) MarshalJSON() ([]byte, error) {
+	// Built by hand rather than parsed, so there's nothing to send back but the type
+	if len(u.Raw) == 0 {
+		return json.Marshal(map[string]component_type.ComponentType{"type": u.ComponentType})
+	}
+
+	return u.Raw, nil
+}
+
+func (u *UnknownComponent) Type() component_type.ComponentType {
+	return u.ComponentType
+}
+
+func (u *UnknownComponent) Verify() error {
+	return nil
+}
+
+func verifyMediaDescription(component MessageComponent, description *string) error {
+	if description != nil && utf8.RuneCountInString(*description) > 1024 {
+		return ErrInvalidPropertyLength{
+			Component:      component,
+			PropertyName:   "description",
+			MaxLength:      1024,
+			MinLength:      0,
+			PropertyLength: utf8.RuneCountInString(*description),
+			PropertyValue:  *description,
+		}
+	}
+
+	return nil
+}
+
+// IsLayoutComponent reports whether the component type is only allowed in IsComponentsV2 messages
+func IsLayoutComponent(componentType component_type.ComponentType) bool {
+	switch componentType {
+	case component_type.Section, component_type.TextDisplay, component_type.Thumbnail,
+		component_type.MediaGallery, component_type.File, component_type.Separator, component_type.Container:
+		return true
+	}
+
+	return false
+}
+
+// IsTopLevelComponent reports whether the component type can be sent directly in an IsComponentsV2 message's
+// components, rather than only inside another component
+func IsTopLevelComponent(componentType component_type.ComponentType) bool {
+	switch componentType {
+	case component_type.ActionRow, component_type.Section, component_type.TextDisplay, component_type.MediaGallery,
+		component_type.File, component_type.Separator, component_type.Container:
+		return true
+	}
+
+	return false
+}
+
+// componentAttachmentRefs returns the filenames of every attachment:// reference in the components
+func componentAttachmentRefs(components []MessageComponent) []string {
+	refs := make([]string, 0)
+
+	addRef := func(media UnfurledMediaItem) {
+		if media.IsAttachmentRef() {
+			refs = append(refs, strings.TrimPrefix(media.Url, attachmentRefPrefix))
+		}
+	}
+
+	WalkComponents(components, func(component MessageComponent) {
+		switch c := component.(type) {
+		case *Thumbnail:
+			addRef(c.Media)
+		case *MediaGallery:
+			for _, item := range c.Items {
+				addRef(item.Media)
+			}
+		case *File:
+			addRef(c.File)
+		}
+	})
+
+	return refs
+}
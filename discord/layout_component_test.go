@@ -0,0 +1,183 @@
+package discord
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord/message_flags"
+	"github.com/JackHumphries9/dapper-go/discord/separator_spacing"
+)
+
+const layoutMessage = `{"components": [
+	{"type": 17, "accent_color": 255, "components": [
+		{"type": 9, "components": [{"type": 10, "content": "Hello"}], "accessory": {"type": 11, "media": {"url": "attachment://cat.png"}}},
+		{"type": 14, "spacing": 2},
+		{"type": 13, "file": {"url": "attachment://report.pdf"}},
+		{"type": 99, "future": true}
+	]}
+]}`
+
+func TestLayoutComponentParsing(t *testing.T) {
+	message := Message{}
+	err := json.Unmarshal([]byte(layoutMessage), &message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	container, ok := message.Components[0].(*Container)
+	if !ok || len(container.Components) != 4 {
+		t.Fatalf("Expected a container with 4 components, got %#v", message.Components[0])
+	}
+
+	section, ok := container.Components[0].(*Section)
+	if !ok || section.Accessory == nil || section.Components[0].(*TextDisplay).Content != "Hello" {
+		t.Errorf("Expected a section with a text display and accessory, got %#v", container.Components[0])
+	}
+
+	unknown, ok := container.Components[3].(*UnknownComponent)
+	if !ok || unknown.Type() != 99 {
+		t.Fatalf("Expected an unknown component of type 99, got %#v", container.Components[3])
+	}
+
+	marshalled, err := json.Marshal(unknown)
+	if err != nil || string(marshalled) != `{"type":99,"future":true}` {
+		t.Errorf("Expected unknown component to marshal unchanged, got %s (%v)", marshalled, err)
+	}
+}
+
+func TestComponentsV2Verify(t *testing.T) {
+	message := Message{}
+	if err := json.Unmarshal([]byte(layoutMessage), &message); err != nil {
+		t.Fatal(err)
+	}
+
+	data := ResponseEditData{Components: message.Components}
+	if !errors.As(data.Verify(), &ErrComponentsV2FlagRequired{}) {
+		t.Errorf("Expected layout components without the flag to fail")
+	}
+
+	data.Flags = new(int)
+	*data.Flags = int(message_flags.IsComponentsV2)
+	if !errors.As(data.Verify(), &ErrUnknownAttachmentReference{}) {
+		t.Errorf("Expected missing attachments to fail")
+	}
+
+	data.Attachments = []MessageAttachment{
+		NewBytesAttachment(nil, "cat.png", "image/png"),
+		NewBytesAttachment(nil, "report.pdf", "application/pdf"),
+	}
+	if err := data.Verify(); err != nil {
+		t.Errorf("Expected message to verify, got %v", err)
+	}
+
+	content := "not allowed"
+	data.Content = &content
+	if !errors.As(data.Verify(), &ErrComponentsV2ContentNotAllowed{}) {
+		t.Errorf("Expected content with the flag to fail")
+	}
+}
+
+func TestComponentsV2VerifyTopLevel(t *testing.T) {
+	flags := int(message_flags.IsComponentsV2)
+
+	data := ResponseEditData{
+		Flags:      &flags,
+		Components: []MessageComponent{&Thumbnail{Media: UnfurledMediaItem{Url: "https://example.com/cat.png"}}},
+	}
+	if !errors.As(data.Verify(), &ErrInvalidTopLevelComponent{}) {
+		t.Errorf("Expected a top level thumbnail to fail")
+	}
+
+	data.Components = []MessageComponent{&TextDisplay{Content: "Hello"}, &UnknownComponent{ComponentType: 99}}
+	if err := data.Verify(); err != nil {
+		t.Errorf("Expected top level text and unknown components to verify, got %v", err)
+	}
+
+	data.Components = []MessageComponent{nil}
+	if !errors.As(data.Verify(), &ErrNilComponent{}) {
+		t.Errorf("Expected a nil component to fail")
+	}
+
+	legacy := ResponseEditData{Components: []MessageComponent{nil}}
+	if !errors.As(legacy.Verify(), &ErrNilComponent{}) {
+		t.Errorf("Expected a nil component without the flag to fail")
+	}
+}
+
+func TestComponentsV2VerifyTextLength(t *testing.T) {
+	flags := int(message_flags.IsComponentsV2)
+
+	// The limit is in characters, not bytes
+	text := &TextDisplay{Content: strings.Repeat("é", MaxComponentsV2TextLength)}
+	data := ResponseEditData{Flags: &flags, Components: []MessageComponent{text}}
+	if err := data.Verify(); err != nil {
+		t.Errorf("Expected %d characters to verify, got %v", MaxComponentsV2TextLength, err)
+	}
+
+	data.Components = append(data.Components, &TextDisplay{Content: "é"})
+	if err := data.Verify(); err == nil {
+		t.Errorf("Expected %d characters to fail", MaxComponentsV2TextLength+1)
+	}
+}
+
+func TestSeparatorVerify(t *testing.T) {
+	spacing := separator_spacing.SeparatorSpacing(3)
+	separator := &Separator{Spacing: &spacing}
+
+	var valueErr ErrInvalidPropertyValue
+	if !errors.As(separator.Verify(), &valueErr) || valueErr.PropertyName != "spacing" {
+		t.Errorf("Expected an invalid spacing error, got %v", separator.Verify())
+	}
+
+	spacing = separator_spacing.Large
+	if err := separator.Verify(); err != nil {
+		t.Errorf("Expected large spacing to verify, got %v", err)
+	}
+}
+
+func TestUnknownComponentMarshalWithoutRaw(t *testing.T) {
+	marshalled, err := json.Marshal([]MessageComponent{&UnknownComponent{ComponentType: 99}})
+	if err != nil || string(marshalled) != `[{"type":99}]` {
+		t.Errorf("Expected an unknown component without raw JSON to marshal its type, got %s (%v)", marshalled, err)
+	}
+}
+
+func TestLayoutComponentsVerifyNilChildren(t *testing.T) {
+	text := &TextDisplay{Content: "Hello"}
+	thumbnail := &Thumbnail{Media: UnfurledMediaItem{Url: "https://example.com/cat.png"}}
+
+	components := []MessageComponent{
+		&Section{Components: []MessageComponent{nil}, Accessory: thumbnail},
+		&Section{Components: []MessageComponent{text, nil}, Accessory: thumbnail},
+		&Container{Components: []MessageComponent{text, nil}},
+		&Container{Components: []MessageComponent{&Section{Components: []MessageComponent{nil}, Accessory: thumbnail}}},
+		&ActionRow{Components: []MessageComponent{nil}},
+	}
+
+	for i, component := range components {
+		if !errors.As(component.Verify(), &ErrComponentMissingProperty{}) {
+			t.Errorf("component %d: expected a nil child to be reported as missing", i)
+		}
+	}
+
+	// Gallery items are values, an empty one fails on its media url rather than panicking
+	gallery := &MediaGallery{Items: []MediaGalleryItem{{}}}
+	if !errors.As(gallery.Verify(), &ErrComponentMissingProperty{}) {
+		t.Error("expected an empty gallery item to be reported as missing its media")
+	}
+}
+
+func TestMediaDescriptionCountsCharacters(t *testing.T) {
+	description := strings.Repeat("é", 1024)
+	thumbnail := &Thumbnail{Media: UnfurledMediaItem{Url: "https://example.com/cat.png"}, Description: &description}
+	if err := thumbnail.Verify(); err != nil {
+		t.Errorf("Expected a 1024 character description to pass, got %v", err)
+	}
+
+	description += "é"
+	if !errors.As(thumbnail.Verify(), &ErrInvalidPropertyLength{}) {
+		t.Error("Expected a 1025 character description to be rejected")
+	}
+}
@@ -1,6 +1,9 @@
 package discord
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type Member struct {
 	User                       *User        `json:"user,omitempty"`
@@ -15,3 +18,16 @@ type Member struct {
 	Permissions                *Permissions `json:"permissions,omitempty"`
 	CommunicationDisabledUntil *time.Time   `json:"communication_disabled_until,omitempty"`
 }
+
+func (member *Member) HasRole(roleId Snowflake) bool {
+	return slices.Contains(member.Roles, roleId)
+}
+
+// IsTimedOut reports whether the member is currently timed out
+func (member *Member) IsTimedOut() bool {
+	return member.isTimedOutAt(time.Now())
+}
+
+func (member *Member) isTimedOutAt(now time.Time) bool {
+	return member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(now)
+}
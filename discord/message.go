@@ -41,6 +41,7 @@ type Message struct {
 	StickerItems         []StickerItem            `json:"sticker_items,omitempty"`
 	Position             *int                     `json:"position,omitempty"`
 	RoleSubscriptionData *RoleSubscriptionData    `json:"role_subscription_data,omitempty"`
+	Poll                 *Poll                    `json:"poll,omitempty"`
 }
 
 func (m *Message) UnmarshalJSON(data []byte) error {
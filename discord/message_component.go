@@ -15,36 +15,125 @@ func (m MessageComponentWrapper) MarshalJSON() ([]byte, error) {
 }
 
 func (m *MessageComponentWrapper) UnmarshalJSON(data []byte) error {
-	var componentMap map[string]interface{}
-	err := json.Unmarshal(data, &componentMap)
+	var typed struct {
+		Type component_type.ComponentType `json:"type"`
+	}
+	err := json.Unmarshal(data, &typed)
 	if err != nil {
 		return err
 	}
-	switch component_type.ComponentType(componentMap["type"].(float64)) {
+	switch typed.Type {
 	case component_type.ActionRow:
 		var actionRow ActionRow
 		err = json.Unmarshal(data, &actionRow)
 		m.component = &actionRow
-		break
 	case component_type.Button:
 		var button Button
 		err = json.Unmarshal(data, &button)
 		m.component = &button
-		break
 	case component_type.StringSelect, component_type.UserSelect, component_type.RoleSelect, component_type.MentionableSelect, component_type.ChannelSelect:
 		var selectMenu SelectMenu
 		err = json.Unmarshal(data, &selectMenu)
 		m.component = &selectMenu
-		break
 	case component_type.TextInput:
 		var textInput TextInput
 		err = json.Unmarshal(data, &textInput)
 		m.component = &textInput
-		break
+	case component_type.Section:
+		var section Section
+		err = json.Unmarshal(data, &section)
+		m.component = &section
+	case component_type.TextDisplay:
+		var textDisplay TextDisplay
+		err = json.Unmarshal(data, &textDisplay)
+		m.component = &textDisplay
+	case component_type.Thumbnail:
+		var thumbnail Thumbnail
+		err = json.Unmarshal(data, &thumbnail)
+		m.component = &thumbnail
+	case component_type.MediaGallery:
+		var mediaGallery MediaGallery
+		err = json.Unmarshal(data, &mediaGallery)
+		m.component = &mediaGallery
+	case component_type.File:
+		var file File
+		err = json.Unmarshal(data, &file)
+		m.component = &file
+	case component_type.Separator:
+		var separator Separator
+		err = json.Unmarshal(data, &separator)
+		m.component = &separator
+	case component_type.Container:
+		var container Container
+		err = json.Unmarshal(data, &container)
+		m.component = &container
+	case component_type.Label:
+		var label Label
+		err = json.Unmarshal(data, &label)
+		m.component = &label
+	case component_type.FileUpload:
+		var fileUpload FileUpload
+		err = json.Unmarshal(data, &fileUpload)
+		m.component = &fileUpload
+	default:
+		m.component = &UnknownComponent{
+			ComponentType: typed.Type,
+			Raw:           append(json.RawMessage(nil), data...),
+		}
 	}
 	return err
 }
 
+func unmarshalComponent(data json.RawMessage) (MessageComponent, error) {
+	var wrapper MessageComponentWrapper
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, err
+	}
+
+	return wrapper.component, nil
+}
+
+func unmarshalComponents(data []json.RawMessage) ([]MessageComponent, error) {
+	components := make([]MessageComponent, 0, len(data))
+
+	for _, raw := range data {
+		component, err := unmarshalComponent(raw)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, component)
+	}
+
+	return components, nil
+}
+
+// WalkComponents calls fn for every component, descending into action rows, sections, containers and labels
+func WalkComponents(components []MessageComponent, fn func(component MessageComponent)) {
+	for _, component := range components {
+		if component == nil {
+			continue
+		}
+
+		fn(component)
+
+		switch c := component.(type) {
+		case *ActionRow:
+			WalkComponents(c.Components, fn)
+		case *Section:
+			WalkComponents(c.Components, fn)
+			if c.Accessory != nil {
+				WalkComponents([]MessageComponent{c.Accessory}, fn)
+			}
+		case *Container:
+			WalkComponents(c.Components, fn)
+		case *Label:
+			if c.Component != nil {
+				WalkComponents([]MessageComponent{c.Component}, fn)
+			}
+		}
+	}
+}
+
 // MessageComponent can be made of any variables, so it's a map until we parse it into a specific component.
 type MessageComponent interface {
 	Type() component_type.ComponentType
@@ -103,6 +192,9 @@ func (a *ActionRow) Verify() error {
 		return ErrTooManyComponents{a.Components}
 	}
 	for _, component := range a.Components {
+		if component == nil {
+			return ErrComponentMissingProperty{a, "components"}
+		}
 		// Check the component is NOT another action row
 		if component.Type() == component_type.ActionRow {
 			return ErrNestedActionRow{a.Components}
@@ -14,6 +14,8 @@ const (
 	FailedToMentionSomeRolesInThread MessageFlags = 1 << 8
 	SupressNotification              MessageFlags = 1 << 12
 	IsVoiceMessage                   MessageFlags = 1 << 13
+	HasSnapshot                      MessageFlags = 1 << 14
+	IsComponentsV2                   MessageFlags = 1 << 15
 )
 
 func (flags MessageFlags) HasFlag(flag MessageFlags) bool {
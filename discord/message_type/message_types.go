@@ -30,4 +30,13 @@ const (
 	GuildApplicationPremiumSubscription = iota + 6
 )
 
+const (
+	GuildIncidentAlertModeEnabled  MessageType = 36
+	GuildIncidentAlertModeDisabled MessageType = 37
+	GuildIncidentReportRaid        MessageType = 38
+	GuildIncidentReportFalseAlarm  MessageType = 39
+	PurchaseNotification           MessageType = 44
+	PollResult                     MessageType = 46
+)
+
 type MessageType uint8
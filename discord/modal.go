@@ -3,6 +3,7 @@ package discord
 import (
 	"encoding/json"
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/JackHumphries9/dapper-go/discord/component_type"
 	"github.com/JackHumphries9/dapper-go/discord/text_input_style"
@@ -11,6 +12,7 @@ import (
 type ModalSubmitData struct {
 	CustomId   string             `json:"custom_id"`
 	Components []MessageComponent `json:"components"`
+	Resolved   *ResolvedData      `json:"resolved,omitempty"`
 }
 
 func (m *ModalSubmitData) UnmarshalJSON(data []byte) error {
@@ -46,14 +48,60 @@ func (m *ModalSubmitData) UnmarshalJSON(data []byte) error {
 		m.Components = append(m.Components, messageComponent.component)
 	}
 
+	if rawResolved, ok := dataMap["resolved"]; ok && rawResolved != nil {
+		resolvedData, err := json.Marshal(rawResolved)
+		if err != nil {
+			return fmt.Errorf("failed to re-marshal resolved: %v", err)
+		}
+
+		m.Resolved = &ResolvedData{}
+		if err := json.Unmarshal(resolvedData, m.Resolved); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// TextInputValues returns the submitted value of every text input, keyed by custom id
+func (m *ModalSubmitData) TextInputValues() map[string]string {
+	values := make(map[string]string)
+
+	WalkComponents(m.Components, func(component MessageComponent) {
+		if c, ok := component.(*TextInput); ok {
+			if c.Value != nil {
+				values[c.CustomId] = *c.Value
+			} else {
+				values[c.CustomId] = ""
+			}
+		}
+	})
+
+	return values
+}
+
+// ComponentValues returns the submitted values of every select and file upload, keyed by custom id.
+// File uploads give attachment ids, which can be looked up in Resolved
+func (m *ModalSubmitData) ComponentValues() map[string][]string {
+	values := make(map[string][]string)
+
+	WalkComponents(m.Components, func(component MessageComponent) {
+		switch c := component.(type) {
+		case *SelectMenu:
+			values[c.CustomId] = c.Values
+		case *FileUpload:
+			values[c.CustomId] = c.Values
+		}
+	})
+
+	return values
+}
+
 type TextInput struct {
 	TextInputType component_type.ComponentType    `json:"type"`
 	CustomId      string                          `json:"custom_id"`
 	Style         text_input_style.TextInputStyle `json:"style"`
-	Label         string                          `json:"label"`
+	Label         string                          `json:"label,omitempty"` // Must be empty inside a Label component
 	MinLength     *int                            `json:"min_length,omitempty"`
 	MaxLength     *int                            `json:"max_length,omitempty"`
 	Required      bool                            `json:"required"`
@@ -76,16 +124,25 @@ func (t *TextInput) Type() component_type.ComponentType {
 }
 
 func (t *TextInput) Verify() error {
+	if t.Label == "" {
+		return ErrComponentMissingProperty{t, "label"}
+	}
+
+	return t.verifyInput()
+}
+
+// verifyInput checks everything but the label, which moves to the Label component when the input is wrapped in one
+func (t *TextInput) verifyInput() error {
 	if t.CustomId == "" {
 		return ErrComponentMustHaveCustomId{t}
 	}
-	if len(t.CustomId) > 100 {
+	if utf8.RuneCountInString(t.CustomId) > 100 {
 		return ErrInvalidPropertyLength{
 			Component:      t,
 			PropertyName:   "custom_id",
 			MaxLength:      100,
 			MinLength:      1,
-			PropertyLength: len(t.CustomId),
+			PropertyLength: utf8.RuneCountInString(t.CustomId),
 			PropertyValue:  t.CustomId,
 		}
 	}
@@ -93,16 +150,13 @@ func (t *TextInput) Verify() error {
 		return ErrComponentMustHaveStyle{t}
 	}
 
-	if t.Label == "" {
-		return ErrComponentMissingProperty{t, "label"}
-	}
-	if len(t.Label) > 45 {
+	if utf8.RuneCountInString(t.Label) > 45 {
 		return ErrInvalidPropertyLength{
 			Component:      t,
 			PropertyName:   "label",
 			MaxLength:      45,
 			MinLength:      1,
-			PropertyLength: len(t.Label),
+			PropertyLength: utf8.RuneCountInString(t.Label),
 			PropertyValue:  t.Label,
 		}
 	}
@@ -118,16 +172,78 @@ func (t *TextInput) Verify() error {
 	// 	}
 	// }
 
-	if len(t.Placeholder) > 100 {
+	if utf8.RuneCountInString(t.Placeholder) > 100 {
 		return ErrInvalidPropertyLength{
 			Component:      t,
 			PropertyName:   "placeholder",
 			MaxLength:      100,
 			MinLength:      1,
-			PropertyLength: len(t.Placeholder),
+			PropertyLength: utf8.RuneCountInString(t.Placeholder),
 			PropertyValue:  t.Placeholder,
 		}
 	}
 
 	return nil
 }
+
+type FileUpload struct {
+	FileUploadType component_type.ComponentType `json:"type"`
+	Id             *int                         `json:"id,omitempty"`
+	CustomId       string                       `json:"custom_id"`
+	MinValues      *int                         `json:"min_values,omitempty"`
+	MaxValues      *int                         `json:"max_values,omitempty"`
+	Required       *bool                        `json:"required,omitempty"`
+	Values         []string                     `json:"values,omitempty"` // Attachment ids, set by Discord in modal submissions
+}
+
+func (f FileUpload) MarshalJSON() ([]byte, error) {
+	type Alias FileUpload
+
+	var inner Alias
+	inner = Alias(f)
+	inner.FileUploadType = component_type.FileUpload
+
+	return json.Marshal(inner)
+}
+
+func (f *FileUpload) Type() component_type.ComponentType {
+	return component_type.FileUpload
+}
+
+func (f *FileUpload) Verify() error {
+	if f.CustomId == "" {
+		return ErrComponentMustHaveCustomId{f}
+	}
+	if utf8.RuneCountInString(f.CustomId) > 100 {
+		return ErrInvalidPropertyLength{
+			Component:      f,
+			PropertyName:   "custom_id",
+			MaxLength:      100,
+			MinLength:      1,
+			PropertyLength: utf8.RuneCountInString(f.CustomId),
+			PropertyValue:  f.CustomId,
+		}
+	}
+	if f.MinValues != nil && (*f.MinValues < 0 || *f.MinValues > 10) {
+		return ErrInvalidPropertyLength{
+			Component:      f,
+			PropertyName:   "min_values",
+			MaxLength:      10,
+			MinLength:      0,
+			PropertyLength: *f.MinValues,
+			PropertyValue:  *f.MinValues,
+		}
+	}
+	if f.MaxValues != nil && (*f.MaxValues < 1 || *f.MaxValues > 10) {
+		return ErrInvalidPropertyLength{
+			Component:      f,
+			PropertyName:   "max_values",
+			MaxLength:      10,
+			MinLength:      1,
+			PropertyLength: *f.MaxValues,
+			PropertyValue:  *f.MaxValues,
+		}
+	}
+
+	return nil
+}
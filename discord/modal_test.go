@@ -0,0 +1,104 @@
+package discord
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/discord/component_type"
+	"github.com/JackHumphries9/dapper-go/discord/text_input_style"
+)
+
+const labelledModalSubmit = `{"custom_id": "report", "components": [
+	{"type": 18, "component": {"type": 4, "custom_id": "reason", "value": "spam"}},
+	{"type": 18, "component": {"type": 5, "custom_id": "who", "values": ["10"]}},
+	{"type": 18, "component": {"type": 19, "custom_id": "proof", "values": ["20"]}}
+], "resolved": {
+	"users": {"10": {"id": "10", "username": "someone"}},
+	"attachments": {"20": {"id": "20", "filename": "proof.png"}}
+}}`
+
+func TestLabelledModalSubmitParsing(t *testing.T) {
+	submitData := ModalSubmitData{}
+	err := json.Unmarshal([]byte(labelledModalSubmit), &submitData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reason := submitData.TextInputValues()["reason"]; reason != "spam" {
+		t.Errorf("Expected reason to be spam, got %s", reason)
+	}
+
+	values := submitData.ComponentValues()
+	if len(values["who"]) != 1 || values["who"][0] != "10" {
+		t.Errorf("Expected who to be [10], got %v", values["who"])
+	}
+
+	attachment := submitData.Resolved.GetAttachment(20)
+	if len(values["proof"]) != 1 || attachment == nil || attachment.Filename != "proof.png" {
+		t.Errorf("Expected proof to resolve to proof.png, got %v %v", values["proof"], attachment)
+	}
+}
+
+func labelledTextInputModal(title string, label string) ModalCallback {
+	return ModalCallback{
+		CustomId: "feedback",
+		Title:    title,
+		Components: []MessageComponent{
+			&Label{
+				Label:     label,
+				Component: &TextInput{CustomId: "message", Style: text_input_style.Long},
+			},
+		},
+	}
+}
+
+func TestModalCallbackVerifyNilComponents(t *testing.T) {
+	modal := labelledTextInputModal("Feedback", "Message")
+	modal.Components = append(modal.Components, nil)
+	if !errors.As(modal.Verify(), &ErrNilComponent{}) {
+		t.Error("Expected a nil top level component to be rejected")
+	}
+
+	modal = labelledTextInputModal("Feedback", "Message")
+	modal.Components = []MessageComponent{&ActionRow{Components: []MessageComponent{nil}}}
+	if !errors.As(modal.Verify(), &ErrComponentMissingProperty{}) {
+		t.Error("Expected a nil text input in an action row to be rejected")
+	}
+
+	modal = labelledTextInputModal("Feedback", "Message")
+	modal.Components[0].(*Label).Component = nil
+	if !errors.As(modal.Verify(), &ErrComponentMissingProperty{}) {
+		t.Error("Expected a label without a component to be rejected")
+	}
+}
+
+func TestModalCallbackVerifyCountsCharacters(t *testing.T) {
+	// Each of these is within the limit in characters but over it in bytes
+	modal := labelledTextInputModal(strings.Repeat("é", 45), strings.Repeat("é", 45))
+	modal.CustomId = strings.Repeat("é", 100)
+	description := strings.Repeat("é", 100)
+	modal.Components[0].(*Label).Description = &description
+	modal.Components[0].(*Label).Component.(*TextInput).Placeholder = strings.Repeat("é", 100)
+	if err := modal.Verify(); err != nil {
+		t.Errorf("Expected non-ASCII text within the limits to pass, got %v", err)
+	}
+
+	modal = labelledTextInputModal(strings.Repeat("é", 46), "Message")
+	if !errors.As(modal.Verify(), &ErrInvalidPropertyLength{}) {
+		t.Error("Expected a 46 character title to be rejected")
+	}
+
+	modal = labelledTextInputModal("Feedback", strings.Repeat("é", 46))
+	if !errors.As(modal.Verify(), &ErrInvalidPropertyLength{}) {
+		t.Error("Expected a 46 character label to be rejected")
+	}
+}
+
+func TestLabelVerifyRejectsUnknownTextInput(t *testing.T) {
+	label := &Label{Label: "Message", Component: &UnknownComponent{ComponentType: component_type.TextInput}}
+	if !errors.As(label.Verify(), &ErrInvalidChildComponent{}) {
+		t.Error("Expected a text input that isn't a TextInput to be rejected")
+	}
+}
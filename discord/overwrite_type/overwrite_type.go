@@ -0,0 +1,8 @@
+package overwrite_type
+
+type OverwriteType int
+
+const (
+	Role   OverwriteType = iota // 0 - The overwrite applies to a role
+	Member                      // 1 - The overwrite applies to a single member
+)
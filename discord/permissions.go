@@ -2,11 +2,174 @@ package discord
 
 import (
 	"encoding/json"
+	"math/bits"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord/overwrite_type"
 )
 
 type Permissions uint64
 
+const (
+	PermissionCreateInstantInvite              Permissions = 1 << 0
+	PermissionKickMembers                      Permissions = 1 << 1
+	PermissionBanMembers                       Permissions = 1 << 2
+	PermissionAdministrator                    Permissions = 1 << 3
+	PermissionManageChannels                   Permissions = 1 << 4
+	PermissionManageGuild                      Permissions = 1 << 5
+	PermissionAddReactions                     Permissions = 1 << 6
+	PermissionViewAuditLog                     Permissions = 1 << 7
+	PermissionPrioritySpeaker                  Permissions = 1 << 8
+	PermissionStream                           Permissions = 1 << 9
+	PermissionViewChannel                      Permissions = 1 << 10
+	PermissionSendMessages                     Permissions = 1 << 11
+	PermissionSendTTSMessages                  Permissions = 1 << 12
+	PermissionManageMessages                   Permissions = 1 << 13
+	PermissionEmbedLinks                       Permissions = 1 << 14
+	PermissionAttachFiles                      Permissions = 1 << 15
+	PermissionReadMessageHistory               Permissions = 1 << 16
+	PermissionMentionEveryone                  Permissions = 1 << 17
+	PermissionUseExternalEmojis                Permissions = 1 << 18
+	PermissionViewGuildInsights                Permissions = 1 << 19
+	PermissionConnect                          Permissions = 1 << 20
+	PermissionSpeak                            Permissions = 1 << 21
+	PermissionMuteMembers                      Permissions = 1 << 22
+	PermissionDeafenMembers                    Permissions = 1 << 23
+	PermissionMoveMembers                      Permissions = 1 << 24
+	PermissionUseVAD                           Permissions = 1 << 25
+	PermissionChangeNickname                   Permissions = 1 << 26
+	PermissionManageNicknames                  Permissions = 1 << 27
+	PermissionManageRoles                      Permissions = 1 << 28
+	PermissionManageWebhooks                   Permissions = 1 << 29
+	PermissionManageGuildExpressions           Permissions = 1 << 30
+	PermissionUseApplicationCommands           Permissions = 1 << 31
+	PermissionRequestToSpeak                   Permissions = 1 << 32
+	PermissionManageEvents                     Permissions = 1 << 33
+	PermissionManageThreads                    Permissions = 1 << 34
+	PermissionCreatePublicThreads              Permissions = 1 << 35
+	PermissionCreatePrivateThreads             Permissions = 1 << 36
+	PermissionUseExternalStickers              Permissions = 1 << 37
+	PermissionSendMessagesInThreads            Permissions = 1 << 38
+	PermissionUseEmbeddedActivities            Permissions = 1 << 39
+	PermissionModerateMembers                  Permissions = 1 << 40
+	PermissionViewCreatorMonetizationAnalytics Permissions = 1 << 41
+	PermissionUseSoundboard                    Permissions = 1 << 42
+	PermissionCreateGuildExpressions           Permissions = 1 << 43
+	PermissionCreateEvents                     Permissions = 1 << 44
+	PermissionUseExternalSounds                Permissions = 1 << 45
+	PermissionSendVoiceMessages                Permissions = 1 << 46
+	PermissionSetVoiceChannelStatus            Permissions = 1 << 48
+	PermissionSendPolls                        Permissions = 1 << 49
+	PermissionUseExternalApps                  Permissions = 1 << 50
+	PermissionPinMessages                      Permissions = 1 << 51
+	PermissionBypassSlowmode                   Permissions = 1 << 52
+
+	PermissionsAll Permissions = 1<<47 - 1 | PermissionSetVoiceChannelStatus | PermissionSendPolls | PermissionUseExternalApps |
+		PermissionPinMessages | PermissionBypassSlowmode
+
+	// Members that can't send messages lose these too, whatever their roles and overwrites say
+	PermissionsRequiringSendMessages = PermissionSendTTSMessages | PermissionMentionEveryone | PermissionAttachFiles |
+		PermissionEmbedLinks
+
+	// Timed out members keep only these permissions
+	PermissionsTimedOut = PermissionViewChannel | PermissionReadMessageHistory
+)
+
+var permissionNames = map[Permissions]string{
+	PermissionCreateInstantInvite:              "Create Instant Invite",
+	PermissionKickMembers:                      "Kick Members",
+	PermissionBanMembers:                       "Ban Members",
+	PermissionAdministrator:                    "Administrator",
+	PermissionManageChannels:                   "Manage Channels",
+	PermissionManageGuild:                      "Manage Server",
+	PermissionAddReactions:                     "Add Reactions",
+	PermissionViewAuditLog:                     "View Audit Log",
+	PermissionPrioritySpeaker:                  "Priority Speaker",
+	PermissionStream:                           "Video",
+	PermissionViewChannel:                      "View Channel",
+	PermissionSendMessages:                     "Send Messages",
+	PermissionSendTTSMessages:                  "Send TTS Messages",
+	PermissionManageMessages:                   "Manage Messages",
+	PermissionEmbedLinks:                       "Embed Links",
+	PermissionAttachFiles:                      "Attach Files",
+	PermissionReadMessageHistory:               "Read Message History",
+	PermissionMentionEveryone:                  "Mention Everyone",
+	PermissionUseExternalEmojis:                "Use External Emojis",
+	PermissionViewGuildInsights:                "View Server Insights",
+	PermissionConnect:                          "Connect",
+	PermissionSpeak:                            "Speak",
+	PermissionMuteMembers:                      "Mute Members",
+	PermissionDeafenMembers:                    "Deafen Members",
+	PermissionMoveMembers:                      "Move Members",
+	PermissionUseVAD:                           "Use Voice Activity",
+	PermissionChangeNickname:                   "Change Nickname",
+	PermissionManageNicknames:                  "Manage Nicknames",
+	PermissionManageRoles:                      "Manage Roles",
+	PermissionManageWebhooks:                   "Manage Webhooks",
+	PermissionManageGuildExpressions:           "Manage Expressions",
+	PermissionUseApplicationCommands:           "Use Application Commands",
+	PermissionRequestToSpeak:                   "Request to Speak",
+	PermissionManageEvents:                     "Manage Events",
+	PermissionManageThreads:                    "Manage Threads",
+	PermissionCreatePublicThreads:              "Create Public Threads",
+	PermissionCreatePrivateThreads:             "Create Private Threads",
+	PermissionUseExternalStickers:              "Use External Stickers",
+	PermissionSendMessagesInThreads:            "Send Messages in Threads",
+	PermissionUseEmbeddedActivities:            "Use Activities",
+	PermissionModerateMembers:                  "Timeout Members",
+	PermissionViewCreatorMonetizationAnalytics: "View Creator Monetization Analytics",
+	PermissionUseSoundboard:                    "Use Soundboard",
+	PermissionCreateGuildExpressions:           "Create Expressions",
+	PermissionCreateEvents:                     "Create Events",
+	PermissionUseExternalSounds:                "Use External Sounds",
+	PermissionSendVoiceMessages:                "Send Voice Messages",
+	PermissionSetVoiceChannelStatus:            "Set Voice Channel Status",
+	PermissionSendPolls:                        "Create Polls",
+	PermissionUseExternalApps:                  "Use External Apps",
+	PermissionPinMessages:                      "Pin Messages",
+	PermissionBypassSlowmode:                   "Bypass Slowmode",
+}
+
+// Has reports whether every one of the given permissions is set
+func (i Permissions) Has(permissions Permissions) bool {
+	return i&permissions == permissions
+}
+
+func (i *Permissions) Add(permissions Permissions) {
+	*i |= permissions
+}
+
+func (i *Permissions) Remove(permissions Permissions) {
+	*i &^= permissions
+}
+
+// Missing returns the permissions out of the given set that aren't set
+func (i Permissions) Missing(permissions Permissions) Permissions {
+	return permissions &^ i
+}
+
+// String lists the permission names as shown in the Discord client, e.g. "Send Messages, Embed Links"
+func (i Permissions) String() string {
+	names := make([]string, 0, bits.OnesCount64(uint64(i)))
+
+	for bit := 0; bit < 64; bit++ {
+		permission := Permissions(1) << bit
+		if i&permission == 0 {
+			continue
+		}
+
+		if name, ok := permissionNames[permission]; ok {
+			names = append(names, name)
+		} else {
+			names = append(names, "Unknown ("+strconv.Itoa(bit)+")")
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
 func (i Permissions) MarshalJSON() ([]byte, error) {
 	return json.Marshal(strconv.FormatUint(uint64(i), 10))
 }
@@ -15,7 +178,7 @@ func (i *Permissions) UnmarshalJSON(b []byte) error {
 	// Try string first
 	var s string
 	if err := json.Unmarshal(b, &s); err == nil {
-		value, err := strconv.ParseInt(s, 10, 64)
+		value, err := strconv.ParseUint(s, 10, 64)
 		if err != nil {
 			return err
 		}
@@ -26,3 +189,91 @@ func (i *Permissions) UnmarshalJSON(b []byte) error {
 	// Fallback to number
 	return json.Unmarshal(b, (*uint64)(i))
 }
+
+// ComputeBasePermissions works out the member's guild-wide permissions from the @everyone role and their roles.
+// The guild owner and administrators get every permission
+func ComputeBasePermissions(guild *Guild, member *Member) Permissions {
+	if member.User != nil && member.User.Id == guild.OwnerId {
+		return PermissionsAll
+	}
+
+	var permissions Permissions
+	for _, role := range guild.Roles {
+		// The @everyone role shares the guild's id
+		if role.Id == guild.Id || member.HasRole(role.Id) {
+			permissions |= role.Permissions
+		}
+	}
+
+	if permissions.Has(PermissionAdministrator) {
+		return PermissionsAll
+	}
+
+	return permissions
+}
+
+// ComputePermissions works out the member's effective permissions in a channel, applying the channel's
+// overwrites on top of their base permissions. Pass a nil channel for guild-wide permissions
+func ComputePermissions(guild *Guild, member *Member, channel *Channel) Permissions {
+	return computePermissions(guild, member, channel, time.Now())
+}
+
+func computePermissions(guild *Guild, member *Member, channel *Channel, now time.Time) Permissions {
+	permissions := ComputeBasePermissions(guild, member)
+	if permissions == PermissionsAll {
+		return PermissionsAll
+	}
+
+	if channel != nil {
+		permissions = applyOverwrites(permissions, guild.Id, member, channel.PermissionOverwrites)
+	}
+
+	if member.isTimedOutAt(now) {
+		permissions &= PermissionsTimedOut
+	}
+
+	// Members that can't see a channel can't do anything in it
+	if channel != nil && !permissions.Has(PermissionViewChannel) {
+		return 0
+	}
+
+	if !permissions.Has(PermissionSendMessages) {
+		permissions &^= PermissionsRequiringSendMessages
+	}
+
+	return permissions
+}
+
+func applyOverwrites(permissions Permissions, guildId Snowflake, member *Member, overwrites []Overwrite) Permissions {
+	var everyone, memberOverwrite *Overwrite
+	var roleAllow, roleDeny Permissions
+
+	for i := range overwrites {
+		overwrite := &overwrites[i]
+
+		switch {
+		case overwrite.Type == overwrite_type.Role && overwrite.Id == guildId:
+			everyone = overwrite
+		case overwrite.Type == overwrite_type.Role && member.HasRole(overwrite.Id):
+			roleAllow |= overwrite.Allow
+			roleDeny |= overwrite.Deny
+		case overwrite.Type == overwrite_type.Member && member.User != nil && overwrite.Id == member.User.Id:
+			memberOverwrite = overwrite
+		}
+	}
+
+	if everyone != nil {
+		permissions &^= everyone.Deny
+		permissions |= everyone.Allow
+	}
+
+	permissions &^= roleDeny
+	permissions |= roleAllow
+
+	if memberOverwrite != nil {
+		permissions &^= memberOverwrite.Deny
+		permissions |= memberOverwrite.Allow
+	}
+
+	return permissions
+}
@@ -0,0 +1,163 @@
+package discord
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord/overwrite_type"
+)
+
+const (
+	testGuildId Snowflake = 100
+	testOwnerId Snowflake = 1
+	testUserId  Snowflake = 2
+	testRoleId  Snowflake = 200
+	testModId   Snowflake = 300
+)
+
+func testGuild() *Guild {
+	return &Guild{
+		Id:      testGuildId,
+		OwnerId: testOwnerId,
+		Roles: []Role{
+			{Id: testGuildId, Permissions: PermissionViewChannel | PermissionSendMessages | PermissionReadMessageHistory},
+			{Id: testRoleId, Permissions: PermissionEmbedLinks},
+			{Id: testModId, Permissions: PermissionAdministrator},
+		},
+	}
+}
+
+func TestPermissionsHelpers(t *testing.T) {
+	permissions := PermissionSendMessages
+	permissions.Add(PermissionEmbedLinks | PermissionAttachFiles)
+	permissions.Remove(PermissionAttachFiles)
+
+	if !permissions.Has(PermissionSendMessages | PermissionEmbedLinks) {
+		t.Errorf("Expected permissions to have Send Messages and Embed Links, got %s", permissions)
+	}
+	if permissions.Has(PermissionSendMessages | PermissionAttachFiles) {
+		t.Errorf("Expected permissions not to have Attach Files")
+	}
+	if missing := permissions.Missing(PermissionSendMessages | PermissionBanMembers); missing != PermissionBanMembers {
+		t.Errorf("Expected Ban Members to be missing, got %s", missing)
+	}
+	if permissions.String() != "Send Messages, Embed Links" {
+		t.Errorf("Expected \"Send Messages, Embed Links\", got %q", permissions.String())
+	}
+}
+
+func TestPermissionsLargeValueParsing(t *testing.T) {
+	var permissions Permissions
+	if err := json.Unmarshal([]byte(`"18446744073709551615"`), &permissions); err != nil {
+		t.Fatal(err)
+	}
+	if permissions != ^Permissions(0) {
+		t.Errorf("Expected all bits to be set, got %d", permissions)
+	}
+}
+
+func TestComputeBasePermissions(t *testing.T) {
+	guild := testGuild()
+
+	member := &Member{User: &User{Id: testUserId}, Roles: []Snowflake{testRoleId}}
+	expected := PermissionViewChannel | PermissionSendMessages | PermissionReadMessageHistory | PermissionEmbedLinks
+	if permissions := ComputeBasePermissions(guild, member); permissions != expected {
+		t.Errorf("Expected %s, got %s", expected, permissions)
+	}
+
+	owner := &Member{User: &User{Id: testOwnerId}}
+	if permissions := ComputeBasePermissions(guild, owner); permissions != PermissionsAll {
+		t.Errorf("Expected the owner to have every permission, got %s", permissions)
+	}
+
+	admin := &Member{User: &User{Id: testUserId}, Roles: []Snowflake{testModId}}
+	if permissions := ComputeBasePermissions(guild, admin); permissions != PermissionsAll {
+		t.Errorf("Expected an administrator to have every permission, got %s", permissions)
+	}
+}
+
+func TestComputePermissionsOverwrites(t *testing.T) {
+	guild := testGuild()
+	member := &Member{User: &User{Id: testUserId}, Roles: []Snowflake{testRoleId}}
+
+	channel := &Channel{
+		PermissionOverwrites: []Overwrite{
+			{Id: testGuildId, Type: overwrite_type.Role, Deny: PermissionSendMessages},
+			{Id: testRoleId, Type: overwrite_type.Role, Allow: PermissionSendMessages | PermissionAttachFiles},
+			{Id: testUserId, Type: overwrite_type.Member, Deny: PermissionAttachFiles},
+		},
+	}
+
+	permissions := ComputePermissions(guild, member, channel)
+	if !permissions.Has(PermissionSendMessages) {
+		t.Errorf("Expected the role overwrite to allow Send Messages, got %s", permissions)
+	}
+	if permissions.Has(PermissionAttachFiles) {
+		t.Errorf("Expected the member overwrite to deny Attach Files, got %s", permissions)
+	}
+
+	hidden := &Channel{
+		PermissionOverwrites: []Overwrite{
+			{Id: testGuildId, Type: overwrite_type.Role, Deny: PermissionViewChannel},
+		},
+	}
+	if permissions := ComputePermissions(guild, member, hidden); permissions != 0 {
+		t.Errorf("Expected no permissions in a hidden channel, got %s", permissions)
+	}
+}
+
+func TestComputePermissionsTimeout(t *testing.T) {
+	guild := testGuild()
+	now := time.Now()
+	until := now.Add(time.Hour)
+
+	member := &Member{User: &User{Id: testUserId}, Roles: []Snowflake{testRoleId}, CommunicationDisabledUntil: &until}
+	if permissions := computePermissions(guild, member, nil, now); permissions != PermissionsTimedOut {
+		t.Errorf("Expected a timed out member to only view and read history, got %s", permissions)
+	}
+
+	admin := &Member{User: &User{Id: testUserId}, Roles: []Snowflake{testModId}, CommunicationDisabledUntil: &until}
+	if permissions := computePermissions(guild, admin, nil, now); permissions != PermissionsAll {
+		t.Errorf("Expected timeouts not to restrict administrators, got %s", permissions)
+	}
+
+	if permissions := computePermissions(guild, member, nil, until.Add(time.Second)); !permissions.Has(PermissionSendMessages) {
+		t.Errorf("Expected an expired timeout not to restrict the member, got %s", permissions)
+	}
+}
+
+func TestComputePermissionsWithoutSendMessages(t *testing.T) {
+	guild := testGuild()
+	member := &Member{User: &User{Id: testUserId}, Roles: []Snowflake{testRoleId}}
+
+	channel := &Channel{
+		PermissionOverwrites: []Overwrite{
+			{Id: testGuildId, Type: overwrite_type.Role, Deny: PermissionSendMessages},
+			{Id: testRoleId, Type: overwrite_type.Role, Allow: PermissionAttachFiles | PermissionMentionEveryone | PermissionSendTTSMessages},
+		},
+	}
+
+	permissions := ComputePermissions(guild, member, channel)
+	if permissions.Has(PermissionSendMessages) {
+		t.Fatalf("Expected Send Messages to be denied, got %s", permissions)
+	}
+	if lost := permissions & PermissionsRequiringSendMessages; lost != 0 {
+		t.Errorf("Expected %s to be implicitly denied without Send Messages", lost)
+	}
+	if !permissions.Has(PermissionViewChannel | PermissionReadMessageHistory) {
+		t.Errorf("Expected unrelated permissions to be kept, got %s", permissions)
+	}
+}
+
+func TestPermissionsAll(t *testing.T) {
+	for permission, name := range permissionNames {
+		if !PermissionsAll.Has(permission) {
+			t.Errorf("Expected PermissionsAll to include %s", name)
+		}
+	}
+
+	if PermissionSetVoiceChannelStatus.String() != "Set Voice Channel Status" {
+		t.Errorf("Expected \"Set Voice Channel Status\", got %q", PermissionSetVoiceChannelStatus.String())
+	}
+}
@@ -0,0 +1,191 @@
+package discord
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/JackHumphries9/dapper-go/discord/message_type"
+	"github.com/JackHumphries9/dapper-go/discord/poll_layout_type"
+)
+
+const (
+	MaxPollQuestionLength = 300
+	MaxPollAnswerLength   = 55
+	MaxPollAnswers        = 10
+	MaxPollDurationHours  = 768 // 32 days
+)
+
+type Poll struct {
+	Question         PollMedia                       `json:"question"`
+	Answers          []PollAnswer                    `json:"answers"`
+	Expiry           *time.Time                      `json:"expiry"` // Nil for polls that never expire
+	AllowMultiselect bool                            `json:"allow_multiselect"`
+	LayoutType       poll_layout_type.PollLayoutType `json:"layout_type"`
+	Results          *PollResults                    `json:"results,omitempty"` // May be missing while Discord is counting votes
+}
+
+// PollMedia is the text (and, for answers, the optional emoji) of a question or answer
+type PollMedia struct {
+	Text  *string `json:"text,omitempty"`
+	Emoji *Emoji  `json:"emoji,omitempty"`
+}
+
+type PollAnswer struct {
+	AnswerId  int       `json:"answer_id,omitempty"` // Set by Discord, starts at 1
+	PollMedia PollMedia `json:"poll_media"`
+}
+
+type PollResults struct {
+	IsFinalized  bool              `json:"is_finalized"`
+	AnswerCounts []PollAnswerCount `json:"answer_counts"`
+}
+
+type PollAnswerCount struct {
+	Id      int  `json:"id"`
+	Count   int  `json:"count"`
+	MeVoted bool `json:"me_voted"`
+}
+
+// GetAnswerCount returns the votes for an answer, answers without votes are left out of the results by Discord
+func (results *PollResults) GetAnswerCount(answerId int) int {
+	if results == nil {
+		return 0
+	}
+
+	for _, count := range results.AnswerCounts {
+		if count.Id == answerId {
+			return count.Count
+		}
+	}
+
+	return 0
+}
+
+// PollCreateRequest is the poll sent when creating a message
+type PollCreateRequest struct {
+	Question         PollMedia                        `json:"question"`
+	Answers          []PollAnswer                     `json:"answers"`
+	Duration         *int                             `json:"duration,omitempty"` // Hours, defaults to 24
+	AllowMultiselect bool                             `json:"allow_multiselect,omitempty"`
+	LayoutType       *poll_layout_type.PollLayoutType `json:"layout_type,omitempty"`
+}
+
+func (poll PollCreateRequest) Verify() error {
+	if poll.Question.Text == nil || *poll.Question.Text == "" {
+		return fmt.Errorf("poll question must have text")
+	}
+
+	if length := utf8.RuneCountInString(*poll.Question.Text); length > MaxPollQuestionLength {
+		return fmt.Errorf("poll question cannot be longer than %d characters (you have %d)", MaxPollQuestionLength, length)
+	}
+
+	if poll.Question.Emoji != nil {
+		return fmt.Errorf("poll question cannot have an emoji")
+	}
+
+	if len(poll.Answers) < 1 || len(poll.Answers) > MaxPollAnswers {
+		return fmt.Errorf("poll must have between 1 and %d answers (you have %d)", MaxPollAnswers, len(poll.Answers))
+	}
+
+	for i, answer := range poll.Answers {
+		if answer.PollMedia.Text == nil || *answer.PollMedia.Text == "" {
+			return fmt.Errorf("poll answer %d must have text", i+1)
+		}
+
+		if length := utf8.RuneCountInString(*answer.PollMedia.Text); length > MaxPollAnswerLength {
+			return fmt.Errorf("poll answer %d cannot be longer than %d characters (you have %d)", i+1, MaxPollAnswerLength, length)
+		}
+	}
+
+	if poll.Duration != nil && (*poll.Duration < 1 || *poll.Duration > MaxPollDurationHours) {
+		return fmt.Errorf("poll duration must be between 1 and %d hours (you have %d)", MaxPollDurationHours, *poll.Duration)
+	}
+
+	return nil
+}
+
+// PollResult is the content of the system message Discord sends when a poll ends
+type PollResult struct {
+	PollMessageId     Snowflake
+	QuestionText      string
+	TotalVotes        int
+	VictorAnswerId    *int // Nil when the poll ended in a tie or without votes
+	VictorAnswerText  *string
+	VictorAnswerVotes int
+	VictorAnswerEmoji *Emoji
+}
+
+// GetPollResult reads the poll_result embed of a PollResult system message
+func (m *Message) GetPollResult() (*PollResult, error) {
+	if m.Type != message_type.PollResult {
+		return nil, fmt.Errorf("message is not a poll result (type %d)", m.Type)
+	}
+
+	var embed *Embed
+	for i := range m.Embeds {
+		if m.Embeds[i].Type == "poll_result" {
+			embed = &m.Embeds[i]
+			break
+		}
+	}
+
+	if embed == nil {
+		return nil, fmt.Errorf("poll result message has no poll_result embed")
+	}
+
+	fields := make(map[string]string, len(embed.Fields))
+	for _, field := range embed.Fields {
+		fields[field.Name] = field.Value
+	}
+
+	result := &PollResult{
+		QuestionText: fields["poll_question_text"],
+	}
+
+	if m.MessageReference != nil {
+		result.PollMessageId = m.MessageReference.MessageId
+	}
+
+	if id, ok := fields["poll_message_id"]; ok {
+		pollMessageId, err := GetSnowflake(id)
+		if err != nil {
+			return nil, err
+		}
+		result.PollMessageId = pollMessageId
+	}
+
+	result.TotalVotes, _ = strconv.Atoi(fields["total_votes"])
+	result.VictorAnswerVotes, _ = strconv.Atoi(fields["victor_answer_votes"])
+
+	if value, ok := fields["victor_answer_id"]; ok {
+		if id, err := strconv.Atoi(value); err == nil {
+			result.VictorAnswerId = &id
+		}
+	}
+
+	if value, ok := fields["victor_answer_text"]; ok {
+		result.VictorAnswerText = &value
+	}
+
+	emojiId, hasId := fields["victor_answer_emoji_id"]
+	emojiName, hasName := fields["victor_answer_emoji_name"]
+	if hasId || hasName {
+		result.VictorAnswerEmoji = &Emoji{}
+		if hasId {
+			if id, err := GetSnowflake(emojiId); err == nil {
+				result.VictorAnswerEmoji.Id = &id
+			}
+		}
+		if hasName {
+			result.VictorAnswerEmoji.Name = &emojiName
+		}
+		if animated, ok := fields["victor_answer_emoji_animated"]; ok {
+			isAnimated := animated == "true"
+			result.VictorAnswerEmoji.Animated = &isAnimated
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,7 @@
+package poll_layout_type
+
+type PollLayoutType int
+
+const (
+	Default PollLayoutType = iota + 1 // 1 - The default layout type
+)
@@ -0,0 +1,63 @@
+package discord
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const pollResultMessage = `{"id": "3", "type": 46, "message_reference": {"message_id": "2"}, "embeds": [{"type": "poll_result", "fields": [
+	{"name": "poll_question_text", "value": "Best fruit?"},
+	{"name": "victor_answer_votes", "value": "4"},
+	{"name": "total_votes", "value": "7"},
+	{"name": "victor_answer_id", "value": "1"},
+	{"name": "victor_answer_text", "value": "Apple"},
+	{"name": "victor_answer_emoji_name", "value": "🍎"}
+]}]}`
+
+func TestPollResultParsing(t *testing.T) {
+	message := Message{}
+	if err := json.Unmarshal([]byte(pollResultMessage), &message); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := message.GetPollResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.PollMessageId != 2 || result.QuestionText != "Best fruit?" || result.TotalVotes != 7 || result.VictorAnswerVotes != 4 {
+		t.Errorf("Unexpected poll result %+v", result)
+	}
+
+	if result.VictorAnswerId == nil || *result.VictorAnswerId != 1 || result.VictorAnswerEmoji == nil || *result.VictorAnswerEmoji.Name != "🍎" {
+		t.Errorf("Expected the victor to be answer 1 with an apple emoji, got %+v", result)
+	}
+}
+
+func TestPollCreateRequestVerifyCountsCharacters(t *testing.T) {
+	// Both limits are in characters, so multi-byte text up to the limit is fine
+	question := strings.Repeat("é", MaxPollQuestionLength)
+	answer := strings.Repeat("🍎", MaxPollAnswerLength)
+
+	poll := PollCreateRequest{
+		Question: PollMedia{Text: &question},
+		Answers:  []PollAnswer{{PollMedia: PollMedia{Text: &answer}}},
+	}
+	if err := poll.Verify(); err != nil {
+		t.Errorf("Expected the poll to verify, got %v", err)
+	}
+
+	longAnswer := answer + "🍎"
+	poll.Answers[0].PollMedia.Text = &longAnswer
+	if err := poll.Verify(); err == nil {
+		t.Errorf("Expected a %d character answer to fail", MaxPollAnswerLength+1)
+	}
+
+	longQuestion := question + "é"
+	poll.Question.Text = &longQuestion
+	poll.Answers[0].PollMedia.Text = &answer
+	if err := poll.Verify(); err == nil {
+		t.Errorf("Expected a %d character question to fail", MaxPollQuestionLength+1)
+	}
+}
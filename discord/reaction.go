@@ -1,7 +1,15 @@
 package discord
 
 type Reaction struct {
-	Count int    `json:"count"`
-	Me    bool   `json:"me"`
-	Emoji *Emoji `json:"emoji"`
+	Count        int                  `json:"count"`
+	CountDetails ReactionCountDetails `json:"count_details"`
+	Me           bool                 `json:"me"`
+	MeBurst      bool                 `json:"me_burst"`
+	Emoji        *Emoji               `json:"emoji"`
+	BurstColors  []string             `json:"burst_colors,omitempty"` // Hex colors used for super reactions
+}
+
+type ReactionCountDetails struct {
+	Burst  int `json:"burst"`
+	Normal int `json:"normal"`
 }
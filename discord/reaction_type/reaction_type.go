@@ -0,0 +1,8 @@
+package reaction_type
+
+type ReactionType int
+
+const (
+	Normal ReactionType = iota // 0 - A regular reaction
+	Burst                      // 1 - A super reaction
+)
@@ -0,0 +1,10 @@
+package recurrence_frequency
+
+type RecurrenceFrequency int
+
+const (
+	Yearly  RecurrenceFrequency = iota // 0
+	Monthly                            // 1
+	Weekly                             // 2
+	Daily                              // 3
+)
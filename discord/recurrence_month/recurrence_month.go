@@ -0,0 +1,18 @@
+package recurrence_month
+
+type RecurrenceMonth int
+
+const (
+	January   RecurrenceMonth = iota + 1 // 1
+	February                             // 2
+	March                                // 3
+	April                                // 4
+	May                                  // 5
+	June                                 // 6
+	July                                 // 7
+	August                               // 8
+	September                            // 9
+	October                              // 10
+	November                             // 11
+	December                             // 12
+)
@@ -0,0 +1,13 @@
+package recurrence_weekday
+
+type RecurrenceWeekday int
+
+const (
+	Monday    RecurrenceWeekday = iota // 0
+	Tuesday                            // 1
+	Wednesday                          // 2
+	Thursday                           // 3
+	Friday                             // 4
+	Saturday                           // 5
+	Sunday                             // 6
+)
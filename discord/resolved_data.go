@@ -5,5 +5,109 @@ type ResolvedData struct {
 	Members     *map[string]*Member     `json:"members,omitempty"`
 	Roles       *map[string]*Role       `json:"roles,omitempty"`
 	Channels    *map[string]*Channel    `json:"channels,omitempty"`
+	Messages    *map[string]*Message    `json:"messages,omitempty"`
 	Attachments *map[string]*Attachment `json:"attachments,omitempty"`
 }
+
+// ResolvedUser is a resolved user, Member is only present when the interaction happened in a guild
+type ResolvedUser struct {
+	User   *User
+	Member *Member
+}
+
+// ResolvedMentionable holds either a user (and possibly their member) or a role
+type ResolvedMentionable struct {
+	User   *User
+	Member *Member
+	Role   *Role
+}
+
+func (resolved *ResolvedData) GetUser(id Snowflake) *User {
+	if resolved == nil || resolved.Users == nil {
+		return nil
+	}
+
+	return (*resolved.Users)[id.String()]
+}
+
+// GetMember returns the partial member for a user. Resolved members don't include the user, so it is attached from Users
+func (resolved *ResolvedData) GetMember(id Snowflake) *Member {
+	if resolved == nil || resolved.Members == nil {
+		return nil
+	}
+
+	member, ok := (*resolved.Members)[id.String()]
+	if !ok || member == nil {
+		return nil
+	}
+
+	// Work on a copy so the shared resolved data isn't changed
+	memberCopy := *member
+	if memberCopy.User == nil {
+		memberCopy.User = resolved.GetUser(id)
+	}
+
+	return &memberCopy
+}
+
+func (resolved *ResolvedData) GetResolvedUser(id Snowflake) *ResolvedUser {
+	user := resolved.GetUser(id)
+	if user == nil {
+		return nil
+	}
+
+	return &ResolvedUser{
+		User:   user,
+		Member: resolved.GetMember(id),
+	}
+}
+
+func (resolved *ResolvedData) GetRole(id Snowflake) *Role {
+	if resolved == nil || resolved.Roles == nil {
+		return nil
+	}
+
+	return (*resolved.Roles)[id.String()]
+}
+
+func (resolved *ResolvedData) GetChannel(id Snowflake) *Channel {
+	if resolved == nil || resolved.Channels == nil {
+		return nil
+	}
+
+	return (*resolved.Channels)[id.String()]
+}
+
+func (resolved *ResolvedData) GetMessage(id Snowflake) *Message {
+	if resolved == nil || resolved.Messages == nil {
+		return nil
+	}
+
+	return (*resolved.Messages)[id.String()]
+}
+
+func (resolved *ResolvedData) GetAttachment(id Snowflake) *Attachment {
+	if resolved == nil || resolved.Attachments == nil {
+		return nil
+	}
+
+	return (*resolved.Attachments)[id.String()]
+}
+
+// GetMentionable looks the id up as a user first, then as a role
+func (resolved *ResolvedData) GetMentionable(id Snowflake) *ResolvedMentionable {
+	if user := resolved.GetResolvedUser(id); user != nil {
+		return &ResolvedMentionable{
+			User:   user.User,
+			Member: user.Member,
+		}
+	}
+
+	if role := resolved.GetRole(id); role != nil {
+		return &ResolvedMentionable{
+			Role: role,
+		}
+	}
+
+	return nil
+}
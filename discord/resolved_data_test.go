@@ -0,0 +1,79 @@
+package discord
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const resolvedDataJson = `{
+	"users": {"1": {"id": "1", "username": "cat"}, "2": {"id": "2", "username": "dog"}},
+	"members": {"1": {"nick": "Kitty", "roles": []}},
+	"roles": {"10": {"id": "10", "name": "Moderator"}},
+	"channels": {"20": {"id": "20", "name": "general"}},
+	"messages": {"30": {"id": "30", "content": "Hello"}},
+	"attachments": {"40": {"id": "40", "filename": "cat.png"}}
+}`
+
+func TestResolvedDataGetters(t *testing.T) {
+	resolved := &ResolvedData{}
+	if err := json.Unmarshal([]byte(resolvedDataJson), resolved); err != nil {
+		t.Fatal(err)
+	}
+
+	if user := resolved.GetUser(2); user == nil || user.Username != "dog" {
+		t.Errorf("Expected user 2 to be dog, got %+v", user)
+	}
+	if role := resolved.GetRole(10); role == nil || role.Name != "Moderator" {
+		t.Errorf("Expected role 10 to be Moderator, got %+v", role)
+	}
+	if channel := resolved.GetChannel(20); channel == nil || channel.Name == nil || *channel.Name != "general" {
+		t.Errorf("Expected channel 20 to be general, got %+v", channel)
+	}
+	if message := resolved.GetMessage(30); message == nil || message.Content != "Hello" {
+		t.Errorf("Expected message 30 to say Hello, got %+v", message)
+	}
+	if attachment := resolved.GetAttachment(40); attachment == nil || attachment.Filename != "cat.png" {
+		t.Errorf("Expected attachment 40 to be cat.png, got %+v", attachment)
+	}
+	if user := resolved.GetUser(99); user != nil {
+		t.Errorf("Expected no user for an unknown id, got %+v", user)
+	}
+
+	user := resolved.GetResolvedUser(2)
+	if user == nil || user.User.Username != "dog" || user.Member != nil {
+		t.Errorf("Expected dog without a member, got %+v", user)
+	}
+
+	mentionable := resolved.GetMentionable(1)
+	if mentionable == nil || mentionable.User == nil || mentionable.Member == nil || mentionable.Role != nil {
+		t.Errorf("Expected user 1 to resolve as a user with a member, got %+v", mentionable)
+	}
+	mentionable = resolved.GetMentionable(10)
+	if mentionable == nil || mentionable.Role == nil || mentionable.User != nil {
+		t.Errorf("Expected role 10 to resolve as a role, got %+v", mentionable)
+	}
+	if resolved.GetMentionable(99) != nil {
+		t.Errorf("Expected no mentionable for an unknown id")
+	}
+
+	var missing *ResolvedData
+	if missing.GetUser(1) != nil || missing.GetMember(1) != nil || missing.GetRole(10) != nil {
+		t.Errorf("Expected a nil resolved data to resolve nothing")
+	}
+}
+
+func TestResolvedDataGetMemberDoesNotModify(t *testing.T) {
+	resolved := &ResolvedData{}
+	if err := json.Unmarshal([]byte(resolvedDataJson), resolved); err != nil {
+		t.Fatal(err)
+	}
+
+	member := resolved.GetMember(1)
+	if member == nil || member.User == nil || member.User.Username != "cat" || member.Nick == nil || *member.Nick != "Kitty" {
+		t.Fatalf("Expected Kitty with their user attached, got %+v", member)
+	}
+
+	if (*resolved.Members)["1"].User != nil {
+		t.Errorf("Expected the resolved member to be left without a user")
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"mime/multipart"
 	"net/http"
+	"unicode/utf8"
 
+	"github.com/JackHumphries9/dapper-go/discord/component_type"
 	"github.com/JackHumphries9/dapper-go/discord/interaction_callback_type"
+	"github.com/JackHumphries9/dapper-go/discord/message_flags"
 )
 
 type InteractionResponse struct {
@@ -62,6 +65,7 @@ type MessageCallbackData struct {
 	Flags           *int               `json:"flags,omitempty"`
 	Components      []MessageComponent `json:"components,omitempty"`
 	Attachments     []Attachment       `json:"attachments,omitempty"`
+	Poll            *PollCreateRequest `json:"poll,omitempty"`
 }
 
 type ResponseEditData struct {
@@ -71,6 +75,17 @@ type ResponseEditData struct {
 	Components        []MessageComponent  `json:"components"`
 	Attachments       []MessageAttachment `json:"-"`
 	DiscordAttachment []Attachment        `json:"attachments"`
+	Flags             *int                `json:"flags,omitempty"`
+	Poll              *PollCreateRequest  `json:"poll,omitempty"` // Only when creating a message, polls cannot be edited
+}
+
+const (
+	MaxComponentsV2Components = 40
+	MaxComponentsV2TextLength = 4000
+)
+
+func (data ResponseEditData) IsComponentsV2() bool {
+	return data.Flags != nil && message_flags.MessageFlags(*data.Flags).HasFlag(message_flags.IsComponentsV2)
 }
 
 func (data *ResponseEditData) ParseAttachments() {
@@ -178,6 +193,16 @@ func (data ResponseEditData) Verify() error {
 		return fmt.Errorf("too many embeds (max 10, you have %d)", len(data.Embeds))
 	}
 
+	if err := data.verifyComponentsV2(); err != nil {
+		return err
+	}
+
+	if data.Poll != nil {
+		if err := data.Poll.Verify(); err != nil {
+			return err
+		}
+	}
+
 	for _, component := range data.Components {
 		if err := component.Verify(); err != nil {
 			return err
@@ -190,6 +215,81 @@ func (data ResponseEditData) Verify() error {
 		}
 	}
 
+	return data.verifyAttachmentRefs()
+}
+
+func (data ResponseEditData) verifyComponentsV2() error {
+	if !data.IsComponentsV2() {
+		for _, component := range data.Components {
+			if component == nil {
+				return ErrNilComponent{}
+			}
+			if IsLayoutComponent(component.Type()) {
+				return ErrComponentsV2FlagRequired{component}
+			}
+		}
+
+		return nil
+	}
+
+	if data.Content != nil && *data.Content != "" {
+		return ErrComponentsV2ContentNotAllowed{"content"}
+	}
+
+	if len(data.Embeds) > 0 {
+		return ErrComponentsV2ContentNotAllowed{"embeds"}
+	}
+
+	for _, component := range data.Components {
+		if component == nil {
+			return ErrNilComponent{}
+		}
+		if _, ok := component.(*UnknownComponent); ok {
+			continue
+		}
+		if !IsTopLevelComponent(component.Type()) {
+			return ErrInvalidTopLevelComponent{component}
+		}
+	}
+
+	count := 0
+	textLength := 0
+	WalkComponents(data.Components, func(component MessageComponent) {
+		count++
+		if textDisplay, ok := component.(*TextDisplay); ok {
+			textLength += utf8.RuneCountInString(textDisplay.Content)
+		}
+	})
+
+	if count > MaxComponentsV2Components {
+		return fmt.Errorf("too many components (max %d including nested components, you have %d)", MaxComponentsV2Components, count)
+	}
+
+	if textLength > MaxComponentsV2TextLength {
+		return fmt.Errorf("text displays cannot be longer than %d characters in total (you have %d)", MaxComponentsV2TextLength, textLength)
+	}
+
+	return nil
+}
+
+// verifyAttachmentRefs checks every attachment://<filename> in the components names a file sent with the message
+func (data ResponseEditData) verifyAttachmentRefs() error {
+	fileNames := make(map[string]bool)
+
+	for _, attachment := range data.Attachments {
+		fileNames[attachment.GetFileName()] = true
+	}
+
+	for _, attachment := range data.DiscordAttachment {
+		fileNames[attachment.Filename] = true
+	}
+
+	for _, ref := range componentAttachmentRefs(data.Components) {
+		if !fileNames[ref] {
+			return ErrUnknownAttachmentReference{ref}
+		}
+	}
+
 	return nil
 }
 
@@ -203,6 +303,69 @@ type ModalCallback struct {
 	Components []MessageComponent `json:"components"`
 }
 
+// Verify checks the modal only contains what Discord allows: labels, text displays and legacy action rows holding a single text input
+func (modal ModalCallback) Verify() error {
+	customIdLength := utf8.RuneCountInString(modal.CustomId)
+	if customIdLength < 1 || customIdLength > 100 {
+		return ErrInvalidPropertyLength{
+			Component:      modal,
+			PropertyName:   "custom_id",
+			MaxLength:      100,
+			MinLength:      1,
+			PropertyLength: customIdLength,
+			PropertyValue:  modal.CustomId,
+		}
+	}
+
+	titleLength := utf8.RuneCountInString(modal.Title)
+	if titleLength < 1 || titleLength > 45 {
+		return ErrInvalidPropertyLength{
+			Component:      modal,
+			PropertyName:   "title",
+			MaxLength:      45,
+			MinLength:      1,
+			PropertyLength: titleLength,
+			PropertyValue:  modal.Title,
+		}
+	}
+
+	if len(modal.Components) < 1 || len(modal.Components) > 5 {
+		return ErrInvalidPropertyLength{
+			Component:      modal,
+			PropertyName:   "components",
+			MaxLength:      5,
+			MinLength:      1,
+			PropertyLength: len(modal.Components),
+			PropertyValue:  modal.Components,
+		}
+	}
+
+	for _, component := range modal.Components {
+		if component == nil {
+			return ErrNilComponent{}
+		}
+
+		switch c := component.(type) {
+		case *ActionRow:
+			if len(c.Components) == 1 && c.Components[0] == nil {
+				return ErrComponentMissingProperty{c, "components"}
+			}
+			if len(c.Components) != 1 || c.Components[0].Type() != component_type.TextInput {
+				return fmt.Errorf("action rows in modals must contain exactly one text input, use a Label for other components")
+			}
+		case *Label, *TextDisplay:
+		default:
+			return fmt.Errorf("component type %d cannot be used at the top level of a modal", component.Type())
+		}
+
+		if err := component.Verify(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func CreatePongResponse() InteractionResponse {
 	return InteractionResponse{
 		Type: interaction_callback_type.Pong,
@@ -0,0 +1,75 @@
+package discord
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord/role_connection_metadata_type"
+)
+
+const MaxRoleConnectionMetadataRecords = 5
+
+var roleConnectionMetadataKeyRegex = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+type ApplicationRoleConnectionMetadata struct {
+	Type                     role_connection_metadata_type.RoleConnectionMetadataType `json:"type"`
+	Key                      string                                                   `json:"key"`
+	Name                     string                                                   `json:"name"`
+	NameLocalizations        map[string]string                                        `json:"name_localizations,omitempty"`
+	Description              string                                                   `json:"description"`
+	DescriptionLocalizations map[string]string                                        `json:"description_localizations,omitempty"`
+}
+
+// ApplicationRoleConnection is the metadata an app has pushed for a user, values are keyed by the metadata record key
+type ApplicationRoleConnection struct {
+	PlatformName     *string           `json:"platform_name,omitempty"`
+	PlatformUsername *string           `json:"platform_username,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+type ErrInvalidRoleConnectionMetadata struct {
+	Metadata ApplicationRoleConnectionMetadata
+	Reason   string
+}
+
+func (e ErrInvalidRoleConnectionMetadata) Error() string {
+	return fmt.Sprintf("invalid role connection metadata %s: %s", e.Metadata.Key, e.Reason)
+}
+
+func (metadata ApplicationRoleConnectionMetadata) Verify() error {
+	if !roleConnectionMetadataKeyRegex.MatchString(metadata.Key) {
+		return ErrInvalidRoleConnectionMetadata{metadata, "key must be 1-50 characters of a-z, 0-9 or _"}
+	}
+
+	if len(metadata.Name) < 1 || len(metadata.Name) > 100 {
+		return ErrInvalidRoleConnectionMetadata{metadata, "name must be 1-100 characters"}
+	}
+
+	if len(metadata.Description) < 1 || len(metadata.Description) > 200 {
+		return ErrInvalidRoleConnectionMetadata{metadata, "description must be 1-200 characters"}
+	}
+
+	if metadata.Type < role_connection_metadata_type.IntegerLessThanOrEqual || metadata.Type > role_connection_metadata_type.BooleanNotEqual {
+		return ErrInvalidRoleConnectionMetadata{metadata, fmt.Sprintf("unknown type %d", metadata.Type)}
+	}
+
+	return nil
+}
+
+func RoleConnectionInteger(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
+
+func RoleConnectionDatetime(value time.Time) string {
+	return value.UTC().Format(time.RFC3339)
+}
+
+func RoleConnectionBoolean(value bool) string {
+	if value {
+		return "1"
+	}
+
+	return "0"
+}
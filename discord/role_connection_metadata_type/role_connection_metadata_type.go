@@ -0,0 +1,14 @@
+package role_connection_metadata_type
+
+type RoleConnectionMetadataType int
+
+const (
+	IntegerLessThanOrEqual     RoleConnectionMetadataType = iota + 1 // 1 - The user's value is less than or equal to the guild's configured value (integer)
+	IntegerGreaterThanOrEqual                                        // 2 - The user's value is greater than or equal to the guild's configured value (integer)
+	IntegerEqual                                                     // 3 - The user's value is equal to the guild's configured value (integer)
+	IntegerNotEqual                                                  // 4 - The user's value is not equal to the guild's configured value (integer)
+	DatetimeLessThanOrEqual                                          // 5 - The user's date is at least the guild's configured number of days old (ISO8601 string)
+	DatetimeGreaterThanOrEqual                                       // 6 - The user's date is at most the guild's configured number of days old (ISO8601 string)
+	BooleanEqual                                                     // 7 - The user's value is equal to the guild's configured value (integer 1 or 0)
+	BooleanNotEqual                                                  // 8 - The user's value is not equal to the guild's configured value (integer 1 or 0)
+)
@@ -0,0 +1,55 @@
+package discord
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord/role_connection_metadata_type"
+)
+
+func TestApplicationRoleConnectionMetadataVerify(t *testing.T) {
+	valid := ApplicationRoleConnectionMetadata{
+		Type:        role_connection_metadata_type.IntegerGreaterThanOrEqual,
+		Key:         "games_played",
+		Name:        "Games played",
+		Description: "Games played at least",
+	}
+	if err := valid.Verify(); err != nil {
+		t.Errorf("Expected metadata to be valid, got %v", err)
+	}
+
+	cases := map[string]func(metadata *ApplicationRoleConnectionMetadata){
+		"uppercase key":    func(metadata *ApplicationRoleConnectionMetadata) { metadata.Key = "Games" },
+		"empty key":        func(metadata *ApplicationRoleConnectionMetadata) { metadata.Key = "" },
+		"long key":         func(metadata *ApplicationRoleConnectionMetadata) { metadata.Key = strings.Repeat("a", 51) },
+		"empty name":       func(metadata *ApplicationRoleConnectionMetadata) { metadata.Name = "" },
+		"long description": func(metadata *ApplicationRoleConnectionMetadata) { metadata.Description = strings.Repeat("a", 201) },
+		"unknown type":     func(metadata *ApplicationRoleConnectionMetadata) { metadata.Type = 9 },
+	}
+
+	for name, modify := range cases {
+		metadata := valid
+		modify(&metadata)
+
+		if !errors.As(metadata.Verify(), &ErrInvalidRoleConnectionMetadata{}) {
+			t.Errorf("Expected an invalid metadata error for %s", name)
+		}
+	}
+}
+
+func TestRoleConnectionValues(t *testing.T) {
+	if value := RoleConnectionInteger(-42); value != "-42" {
+		t.Errorf("Expected -42, got %s", value)
+	}
+
+	date := time.Date(2026, 1, 5, 18, 0, 0, 0, time.FixedZone("UTC+1", 3600))
+	if value := RoleConnectionDatetime(date); value != "2026-01-05T17:00:00Z" {
+		t.Errorf("Expected the date in UTC, got %s", value)
+	}
+
+	if RoleConnectionBoolean(true) != "1" || RoleConnectionBoolean(false) != "0" {
+		t.Errorf("Expected booleans to be 1 and 0")
+	}
+}
@@ -0,0 +1,168 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/JackHumphries9/dapper-go/discord/recurrence_frequency"
+	"github.com/JackHumphries9/dapper-go/discord/recurrence_month"
+	"github.com/JackHumphries9/dapper-go/discord/recurrence_weekday"
+	"github.com/JackHumphries9/dapper-go/discord/scheduled_event_entity_type"
+	"github.com/JackHumphries9/dapper-go/discord/scheduled_event_privacy_level"
+	"github.com/JackHumphries9/dapper-go/discord/scheduled_event_status"
+)
+
+const (
+	MaxScheduledEventNameLength        = 100
+	MaxScheduledEventDescriptionLength = 1000
+	MaxScheduledEventLocationLength    = 100
+)
+
+type GuildScheduledEvent struct {
+	Id                 Snowflake                                                `json:"id"`
+	GuildId            Snowflake                                                `json:"guild_id"`
+	ChannelId          *Snowflake                                               `json:"channel_id,omitempty"` // Nil for External events
+	CreatorId          *Snowflake                                               `json:"creator_id,omitempty"`
+	Name               string                                                   `json:"name"`
+	Description        *string                                                  `json:"description,omitempty"`
+	ScheduledStartTime time.Time                                                `json:"scheduled_start_time"`
+	ScheduledEndTime   *time.Time                                               `json:"scheduled_end_time,omitempty"` // Always set for External events
+	PrivacyLevel       scheduled_event_privacy_level.ScheduledEventPrivacyLevel `json:"privacy_level"`
+	Status             scheduled_event_status.ScheduledEventStatus              `json:"status"`
+	EntityType         scheduled_event_entity_type.ScheduledEventEntityType     `json:"entity_type"`
+	EntityId           *Snowflake                                               `json:"entity_id,omitempty"`
+	EntityMetadata     *ScheduledEventEntityMetadata                            `json:"entity_metadata,omitempty"`
+	Creator            *User                                                    `json:"creator,omitempty"`
+	UserCount          *int                                                     `json:"user_count,omitempty"` // Only set when requested with the user count
+	Image              *string                                                  `json:"image,omitempty"`
+	RecurrenceRule     *RecurrenceRule                                          `json:"recurrence_rule,omitempty"`
+}
+
+type ScheduledEventEntityMetadata struct {
+	// Required for External events
+	Location *string `json:"location,omitempty"`
+}
+
+type GuildScheduledEventUser struct {
+	GuildScheduledEventId Snowflake `json:"guild_scheduled_event_id"`
+	User                  User      `json:"user"`
+	Member                *Member   `json:"member,omitempty"` // Only set when requested with member data
+}
+
+// RecurrenceRule describes how an event repeats. Discord only supports a subset of iCalendar rules, see Verify
+type RecurrenceRule struct {
+	Start      time.Time                                `json:"start"`
+	End        *time.Time                               `json:"end,omitempty"` // Can't be set yet
+	Frequency  recurrence_frequency.RecurrenceFrequency `json:"frequency"`
+	Interval   int                                      `json:"interval"` // Must be at least 1
+	ByWeekday  []recurrence_weekday.RecurrenceWeekday   `json:"by_weekday,omitempty"`
+	ByNWeekday []RecurrenceNWeekday                     `json:"by_n_weekday,omitempty"`
+	ByMonth    []recurrence_month.RecurrenceMonth       `json:"by_month,omitempty"`
+	ByMonthDay []int                                    `json:"by_month_day,omitempty"`
+	ByYearDay  []int                                    `json:"by_year_day,omitempty"` // Set by Discord
+	Count      *int                                     `json:"count,omitempty"`       // Can't be set yet
+}
+
+// RecurrenceNWeekday is a day in a week of the month, e.g. the 2nd (N) Tuesday (Day)
+type RecurrenceNWeekday struct {
+	N   int                                  `json:"n"`
+	Day recurrence_weekday.RecurrenceWeekday `json:"day"`
+}
+
+// Verify checks the rule is one of the combinations Discord accepts: daily (optionally on a set of weekdays),
+// weekly or every other week on one weekday, monthly on the nth weekday, or yearly on a date
+func (rule RecurrenceRule) Verify() error {
+	if rule.End != nil || rule.Count != nil || len(rule.ByYearDay) > 0 {
+		return fmt.Errorf("recurrence rule end, count and by year day can't be set")
+	}
+
+	// Discord rejects a missing or zero interval rather than defaulting it
+	if rule.Interval < 1 {
+		return fmt.Errorf("recurrence rule interval must be at least 1 (you have %d)", rule.Interval)
+	}
+	interval := rule.Interval
+
+	switch rule.Frequency {
+	case recurrence_frequency.Daily:
+		if len(rule.ByNWeekday) > 0 || len(rule.ByMonth) > 0 || len(rule.ByMonthDay) > 0 {
+			return fmt.Errorf("daily recurrence rules can only set by weekday")
+		}
+		if interval != 1 {
+			return fmt.Errorf("daily recurrence rules must have an interval of 1")
+		}
+	case recurrence_frequency.Weekly:
+		if len(rule.ByNWeekday) > 0 || len(rule.ByMonth) > 0 || len(rule.ByMonthDay) > 0 {
+			return fmt.Errorf("weekly recurrence rules can only set by weekday")
+		}
+		if len(rule.ByWeekday) != 1 {
+			return fmt.Errorf("weekly recurrence rules must have exactly one weekday (you have %d)", len(rule.ByWeekday))
+		}
+		if interval != 1 && interval != 2 {
+			return fmt.Errorf("weekly recurrence rules must have an interval of 1 or 2")
+		}
+	case recurrence_frequency.Monthly:
+		if len(rule.ByWeekday) > 0 || len(rule.ByMonth) > 0 || len(rule.ByMonthDay) > 0 {
+			return fmt.Errorf("monthly recurrence rules can only set by n weekday")
+		}
+		if len(rule.ByNWeekday) != 1 {
+			return fmt.Errorf("monthly recurrence rules must have exactly one n weekday (you have %d)", len(rule.ByNWeekday))
+		}
+		if rule.ByNWeekday[0].N < 1 || rule.ByNWeekday[0].N > 5 {
+			return fmt.Errorf("monthly recurrence rule week must be between 1 and 5 (you have %d)", rule.ByNWeekday[0].N)
+		}
+		if interval != 1 {
+			return fmt.Errorf("monthly recurrence rules must have an interval of 1")
+		}
+	case recurrence_frequency.Yearly:
+		if len(rule.ByWeekday) > 0 || len(rule.ByNWeekday) > 0 {
+			return fmt.Errorf("yearly recurrence rules can only set by month and by month day")
+		}
+		if len(rule.ByMonth) != 1 || len(rule.ByMonthDay) != 1 {
+			return fmt.Errorf("yearly recurrence rules must have exactly one month and month day")
+		}
+		if interval != 1 {
+			return fmt.Errorf("yearly recurrence rules must have an interval of 1")
+		}
+	default:
+		return fmt.Errorf("unknown recurrence frequency %d", rule.Frequency)
+	}
+
+	return nil
+}
+
+// VerifyScheduledEventEntity checks an event has what its entity type needs: stage and voice events need a channel,
+// external events need a location and end time and can't have a channel
+func VerifyScheduledEventEntity(entityType scheduled_event_entity_type.ScheduledEventEntityType, channelId *Snowflake,
+	metadata *ScheduledEventEntityMetadata, startTime time.Time, endTime *time.Time) error {
+	switch entityType {
+	case scheduled_event_entity_type.StageInstance, scheduled_event_entity_type.Voice:
+		if channelId == nil {
+			return fmt.Errorf("stage and voice events must have a channel")
+		}
+		if metadata != nil && metadata.Location != nil {
+			return fmt.Errorf("stage and voice events cannot have a location")
+		}
+	case scheduled_event_entity_type.External:
+		if channelId != nil {
+			return fmt.Errorf("external events cannot have a channel")
+		}
+		if metadata == nil || metadata.Location == nil || *metadata.Location == "" {
+			return fmt.Errorf("external events must have a location")
+		}
+		if length := utf8.RuneCountInString(*metadata.Location); length > MaxScheduledEventLocationLength {
+			return fmt.Errorf("event location cannot be longer than %d characters (you have %d)", MaxScheduledEventLocationLength, length)
+		}
+		if endTime == nil {
+			return fmt.Errorf("external events must have an end time")
+		}
+	default:
+		return fmt.Errorf("unknown scheduled event entity type %d", entityType)
+	}
+
+	if endTime != nil && !startTime.IsZero() && !endTime.After(startTime) {
+		return fmt.Errorf("event end time must be after its start time")
+	}
+
+	return nil
+}
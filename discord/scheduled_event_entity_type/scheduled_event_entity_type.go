@@ -0,0 +1,9 @@
+package scheduled_event_entity_type
+
+type ScheduledEventEntityType int
+
+const (
+	StageInstance ScheduledEventEntityType = 1 // Takes place in a stage channel
+	Voice         ScheduledEventEntityType = 2 // Takes place in a voice channel
+	External      ScheduledEventEntityType = 3 // Takes place somewhere else, with a location
+)
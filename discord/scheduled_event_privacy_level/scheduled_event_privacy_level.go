@@ -0,0 +1,7 @@
+package scheduled_event_privacy_level
+
+type ScheduledEventPrivacyLevel int
+
+const (
+	GuildOnly ScheduledEventPrivacyLevel = 2 // The event is only accessible to guild members
+)
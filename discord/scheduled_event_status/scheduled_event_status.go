@@ -0,0 +1,23 @@
+package scheduled_event_status
+
+type ScheduledEventStatus int
+
+const (
+	Scheduled ScheduledEventStatus = 1 // The event hasn't started yet
+	Active    ScheduledEventStatus = 2 // The event is happening
+	Completed ScheduledEventStatus = 3 // The event has ended
+	Canceled  ScheduledEventStatus = 4 // The event was cancelled before it started
+)
+
+// CanTransitionTo reports whether Discord allows an event to be moved from this status to the next one.
+// Scheduled events can start or be cancelled and active events can be completed, nothing else can change
+func (status ScheduledEventStatus) CanTransitionTo(next ScheduledEventStatus) bool {
+	switch status {
+	case Scheduled:
+		return next == Active || next == Canceled
+	case Active:
+		return next == Completed
+	default:
+		return false
+	}
+}
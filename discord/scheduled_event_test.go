@@ -0,0 +1,93 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord/recurrence_frequency"
+	"github.com/JackHumphries9/dapper-go/discord/recurrence_month"
+	"github.com/JackHumphries9/dapper-go/discord/recurrence_weekday"
+	"github.com/JackHumphries9/dapper-go/discord/scheduled_event_entity_type"
+	"github.com/JackHumphries9/dapper-go/discord/scheduled_event_status"
+)
+
+func TestRecurrenceRuleVerify(t *testing.T) {
+	start := time.Date(2026, 1, 5, 18, 0, 0, 0, time.UTC)
+
+	valid := []RecurrenceRule{
+		{Start: start, Frequency: recurrence_frequency.Daily, Interval: 1},
+		{Start: start, Frequency: recurrence_frequency.Weekly, Interval: 2, ByWeekday: []recurrence_weekday.RecurrenceWeekday{recurrence_weekday.Monday}},
+		{Start: start, Frequency: recurrence_frequency.Monthly, Interval: 1, ByNWeekday: []RecurrenceNWeekday{{N: 1, Day: recurrence_weekday.Monday}}},
+		{Start: start, Frequency: recurrence_frequency.Yearly, Interval: 1, ByMonth: []recurrence_month.RecurrenceMonth{recurrence_month.January}, ByMonthDay: []int{5}},
+	}
+	for _, rule := range valid {
+		if err := rule.Verify(); err != nil {
+			t.Errorf("Expected frequency %d to be valid, got %v", rule.Frequency, err)
+		}
+	}
+
+	invalid := []RecurrenceRule{
+		{Start: start, Frequency: recurrence_frequency.Daily, Interval: 2},
+		{Start: start, Frequency: recurrence_frequency.Weekly, Interval: 1},
+		{Start: start, Frequency: recurrence_frequency.Monthly, Interval: 1, ByNWeekday: []RecurrenceNWeekday{{N: 6}}},
+		{Start: start, Frequency: recurrence_frequency.Yearly, Interval: 1, ByMonth: []recurrence_month.RecurrenceMonth{recurrence_month.January}},
+		// Discord doesn't default a missing interval
+		{Start: start, Frequency: recurrence_frequency.Daily},
+	}
+	for _, rule := range invalid {
+		if err := rule.Verify(); err == nil {
+			t.Errorf("Expected an error for frequency %d", rule.Frequency)
+		}
+	}
+}
+
+func TestVerifyScheduledEventEntity(t *testing.T) {
+	start := time.Now().Add(time.Hour)
+	end := start.Add(time.Hour)
+	channelId := Snowflake(1)
+	location := "The park"
+
+	if err := VerifyScheduledEventEntity(scheduled_event_entity_type.Voice, &channelId, nil, start, nil); err != nil {
+		t.Errorf("Expected a voice event with a channel to be valid, got %v", err)
+	}
+	if err := VerifyScheduledEventEntity(scheduled_event_entity_type.Voice, nil, nil, start, nil); err == nil {
+		t.Errorf("Expected a voice event without a channel to be invalid")
+	}
+
+	metadata := &ScheduledEventEntityMetadata{Location: &location}
+	if err := VerifyScheduledEventEntity(scheduled_event_entity_type.External, nil, metadata, start, &end); err != nil {
+		t.Errorf("Expected an external event with a location and end time to be valid, got %v", err)
+	}
+	if err := VerifyScheduledEventEntity(scheduled_event_entity_type.External, nil, metadata, start, nil); err == nil {
+		t.Errorf("Expected an external event without an end time to be invalid")
+	}
+	if err := VerifyScheduledEventEntity(scheduled_event_entity_type.External, nil, metadata, end, &start); err == nil {
+		t.Errorf("Expected an event ending before it starts to be invalid")
+	}
+	if err := VerifyScheduledEventEntity(scheduled_event_entity_type.External, &channelId, metadata, start, &end); err == nil {
+		t.Errorf("Expected an external event with a channel to be invalid")
+	}
+
+	// The limit is in characters, not bytes
+	location = strings.Repeat("é", MaxScheduledEventLocationLength)
+	if err := VerifyScheduledEventEntity(scheduled_event_entity_type.External, nil, metadata, start, &end); err != nil {
+		t.Errorf("Expected a %d character location to be valid, got %v", MaxScheduledEventLocationLength, err)
+	}
+	location += "é"
+	if err := VerifyScheduledEventEntity(scheduled_event_entity_type.External, nil, metadata, start, &end); err == nil {
+		t.Errorf("Expected a %d character location to be invalid", MaxScheduledEventLocationLength+1)
+	}
+}
+
+func TestScheduledEventStatusTransitions(t *testing.T) {
+	if !scheduled_event_status.Scheduled.CanTransitionTo(scheduled_event_status.Active) {
+		t.Errorf("Expected scheduled events to be able to start")
+	}
+	if scheduled_event_status.Active.CanTransitionTo(scheduled_event_status.Canceled) {
+		t.Errorf("Expected active events not to be cancellable")
+	}
+	if scheduled_event_status.Completed.CanTransitionTo(scheduled_event_status.Active) {
+		t.Errorf("Expected completed events not to restart")
+	}
+}
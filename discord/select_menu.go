@@ -14,6 +14,8 @@ type SelectMenu struct {
 	MinValues    *uint8                       `json:"min_values,omitempty"`
 	MaxValues    *uint8                       `json:"max_values,omitempty"`
 	Disabled     *bool                        `json:"disabled,omitempty"`
+	Required     *bool                        `json:"required,omitempty"` // Only in modals
+	Values       []string                     `json:"values,omitempty"`   // Set by Discord in modal submissions
 }
 
 type SelectOption struct {
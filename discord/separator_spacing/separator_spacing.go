@@ -0,0 +1,8 @@
+package separator_spacing
+
+type SeparatorSpacing int
+
+const (
+	Small SeparatorSpacing = iota + 1 // 1 - Small padding
+	Large                             // 2 - Large padding
+)
@@ -3,12 +3,19 @@ package discord
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
 	"time"
 )
 
 type Snowflake uint64
 
+// DiscordEpoch is the first millisecond of 2015 in unix milliseconds, snowflake timestamps count from it
+const DiscordEpoch = 1420070400000
+
+// Larger float64 values can't be told apart from their neighbours
+const maxExactFloatSnowflake = 1 << 53
+
 func GetSnowflake(id any) (Snowflake, error) {
 	switch id.(type) {
 	case string:
@@ -18,13 +25,30 @@ func GetSnowflake(id any) (Snowflake, error) {
 		return Snowflake(id.(int)), nil
 	case int64:
 		return Snowflake(id.(int64)), nil
-	default:
+	case uint64:
 		return Snowflake(id.(uint64)), nil
+	case float64:
+		// JSON numbers are decoded as float64, which can only hold whole numbers exactly up to 2^53.
+		// Real snowflakes are larger than that, so they should always be sent as strings
+		value := id.(float64)
+		if value < 0 || value > maxExactFloatSnowflake || value != math.Trunc(value) {
+			return 0, fmt.Errorf("cannot convert %v to a snowflake exactly, send it as a string", value)
+		}
+		return Snowflake(value), nil
+	case Snowflake:
+		return id.(Snowflake), nil
+	default:
+		return 0, fmt.Errorf("cannot convert %T to a snowflake", id)
 	}
 }
 
 func SnowflakeFromTime(t time.Time) Snowflake {
-	return Snowflake((t.UnixMilli() - 1420070400000) << 22)
+	return Snowflake((t.UnixMilli() - DiscordEpoch) << 22)
+}
+
+// Timestamp returns when the snowflake was created
+func (i Snowflake) Timestamp() time.Time {
+	return time.UnixMilli(int64(i>>22) + DiscordEpoch)
 }
 
 func (i Snowflake) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,45 @@
+package discord
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetSnowflake(t *testing.T) {
+	valid := map[any]Snowflake{
+		"175928847299117063": 175928847299117063,
+		175928847299117063:   175928847299117063,
+		int64(42):            42,
+		uint64(42):           42,
+		Snowflake(42):        42,
+		float64(42):          42,
+		float64(1 << 53):     1 << 53,
+	}
+	for id, expected := range valid {
+		if snowflake, err := GetSnowflake(id); err != nil || snowflake != expected {
+			t.Errorf("Expected %v (%T) to be %d, got %d (%v)", id, id, expected, snowflake, err)
+		}
+	}
+
+	// Floats that aren't exact whole numbers would silently become the wrong id
+	invalid := []any{
+		float64(175928847299117063),
+		float64(1<<53 + 2),
+		1.5,
+		float64(-1),
+		"not a number",
+		true,
+	}
+	for _, id := range invalid {
+		if snowflake, err := GetSnowflake(id); err == nil {
+			t.Errorf("Expected %v (%T) to be rejected, got %d", id, id, snowflake)
+		}
+	}
+}
+
+func TestSnowflakeTimestamp(t *testing.T) {
+	now := time.UnixMilli(time.Now().UnixMilli())
+	if timestamp := SnowflakeFromTime(now).Timestamp(); !timestamp.Equal(now) {
+		t.Errorf("Expected %v, got %v", now, timestamp)
+	}
+}
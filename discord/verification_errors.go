@@ -260,3 +260,82 @@ func (e ErrInvalidParseGroup) Error() string {
 
 	return fmt.Sprintf("invalid parse group \"%s\"\nif you have this parse group, you cannot also provide options for the %s field in the allowed mentions object: %s\n", e.InvalidGroup, e.InvalidGroup, jsonRepresentation)
 }
+
+type ErrInvalidChildComponent struct {
+	Parent MessageComponent
+	Child  MessageComponent
+}
+
+func (e ErrInvalidChildComponent) Error() string {
+	var componentText string
+	componentMarshalled, err := json.Marshal(e.Parent)
+	if err != nil {
+		componentText = fmt.Sprintf("%v", e.Parent)
+	} else {
+		componentText = string(componentMarshalled)
+	}
+	return fmt.Sprintf("component type %d cannot contain component type %d\nComponent:%s\n", e.Parent.Type(), e.Child.Type(), componentText)
+}
+
+type ErrFileMustReferenceAttachment struct {
+	Component *File
+}
+
+func (e ErrFileMustReferenceAttachment) Error() string {
+	return fmt.Sprintf("file component must reference an uploaded file with attachment://<filename> (got %s)\n", e.Component.File.Url)
+}
+
+type ErrComponentsV2FlagRequired struct {
+	Component MessageComponent
+}
+
+func (e ErrComponentsV2FlagRequired) Error() string {
+	return fmt.Sprintf("component type %d can only be sent with the IsComponentsV2 message flag\n", e.Component.Type())
+}
+
+type ErrInvalidTopLevelComponent struct {
+	Component MessageComponent
+}
+
+func (e ErrInvalidTopLevelComponent) Error() string {
+	return fmt.Sprintf("component type %d cannot be sent at the top level of an IsComponentsV2 message, put it inside another component\n", e.Component.Type())
+}
+
+type ErrNilComponent struct{}
+
+func (e ErrNilComponent) Error() string {
+	return "components cannot contain nil\n"
+}
+
+type ErrInvalidPropertyValue struct {
+	Component     MessageComponent
+	PropertyName  string
+	PropertyValue interface{}
+}
+
+func (e ErrInvalidPropertyValue) Error() string {
+	var componentText string
+	componentMarshalled, err := json.Marshal(e.Component)
+	if err != nil {
+		componentText = fmt.Sprintf("%v", e.Component)
+	} else {
+		componentText = string(componentMarshalled)
+	}
+	return fmt.Sprintf("invalid value %v for property %s\nComponent:%s\n", e.PropertyValue, e.PropertyName, componentText)
+}
+
+type ErrComponentsV2ContentNotAllowed struct {
+	PropertyName string
+}
+
+func (e ErrComponentsV2ContentNotAllowed) Error() string {
+	return fmt.Sprintf("messages with the IsComponentsV2 flag cannot have %s, use a TextDisplay component instead\n", e.PropertyName)
+}
+
+type ErrUnknownAttachmentReference struct {
+	FileName string
+}
+
+func (e ErrUnknownAttachmentReference) Error() string {
+	return fmt.Sprintf("attachment://%s does not match any attachment sent with the message\n", e.FileName)
+}
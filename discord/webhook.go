@@ -26,6 +26,7 @@ type WebhookRequest struct {
 	Components      []MessageComponent `json:"components,omitempty"`
 	Attachments     []Attachment       `json:"attachments,omitempty"`
 	ThreadName      string             `json:"thread_name,omitempty"`
+	Poll            *PollCreateRequest `json:"poll,omitempty"`
 }
 
 type WebhookMessageResponse struct {
@@ -85,6 +86,12 @@ func (hook *Webhook) Send(req WebhookRequest) (returnedMessage *Message, err err
 }
 
 func (hook *Webhook) SendWithContext(ctx context.Context, req WebhookRequest) (returnedMessage *Message, err error) {
+	if req.Poll != nil {
+		if err := req.Poll.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid poll: %w", err)
+		}
+	}
+
 	data, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling data to JSON: %w", err)
@@ -0,0 +1,131 @@
+package discord
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord/integration_type"
+	"github.com/JackHumphries9/dapper-go/discord/oauth_scopes"
+	"github.com/JackHumphries9/dapper-go/discord/webhook_event_type"
+	"github.com/JackHumphries9/dapper-go/discord/webhook_type"
+)
+
+// Discord sends event timestamps without a timezone
+const webhookEventTimestampLayout = "2006-01-02T15:04:05.999999"
+
+type WebhookEventPayload struct {
+	Version       int                      `json:"version"`
+	ApplicationId Snowflake                `json:"application_id"`
+	Type          webhook_type.WebhookType `json:"type"`
+	Event         *WebhookEvent            `json:"event,omitempty"`
+}
+
+type WebhookEvent struct {
+	Type         webhook_event_type.WebhookEventType `json:"type"`
+	Timestamp    string                              `json:"timestamp"`
+	DataInternal *json.RawMessage                    `json:"data,omitempty"`
+
+	// Data is one of the *Event types below, or nil for event types without a model
+	Data any `json:"-"`
+}
+
+type ApplicationAuthorizedEvent struct {
+	IntegrationType *integration_type.IntegrationType `json:"integration_type,omitempty"`
+	User            User                              `json:"user"`
+	Scopes          []oauth_scopes.OAuthScope         `json:"scopes"`
+	Guild           *Guild                            `json:"guild,omitempty"`
+}
+
+type ApplicationDeauthorizedEvent struct {
+	User User `json:"user"`
+}
+
+type EntitlementCreateEvent struct {
+	Entitlement
+}
+
+type EntitlementUpdateEvent struct {
+	Entitlement
+}
+
+type EntitlementDeleteEvent struct {
+	Entitlement
+}
+
+// QuestUserEnrollmentEvent is sent when a user enrolls in one of the app's quests. Discord hasn't documented
+// the data yet, so it is kept unparsed in Raw
+type QuestUserEnrollmentEvent struct {
+	Raw json.RawMessage
+}
+
+func ParseWebhookEvent(data string) (payload *WebhookEventPayload, err error) {
+	err = json.Unmarshal([]byte(data), &payload)
+	return payload, err
+}
+
+func (payload *WebhookEventPayload) IsPing() bool {
+	return payload.Type == webhook_type.Ping
+}
+
+func (event *WebhookEvent) UnmarshalJSON(d []byte) error {
+	type InnerWebhookEvent WebhookEvent
+
+	var inner InnerWebhookEvent
+
+	if err := json.Unmarshal(d, &inner); err != nil {
+		return err
+	}
+
+	castEvent := WebhookEvent(inner)
+
+	err := castEvent.createData()
+	if err != nil {
+		return err
+	}
+
+	*event = castEvent
+
+	return nil
+}
+
+func (event *WebhookEvent) createData() (err error) {
+	if event.Data != nil || event.DataInternal == nil {
+		return
+	}
+
+	switch event.Type {
+	case webhook_event_type.ApplicationAuthorized:
+		data := ApplicationAuthorizedEvent{}
+		err = json.Unmarshal(*event.DataInternal, &data)
+		event.Data = &data
+	case webhook_event_type.ApplicationDeauthorized:
+		data := ApplicationDeauthorizedEvent{}
+		err = json.Unmarshal(*event.DataInternal, &data)
+		event.Data = &data
+	case webhook_event_type.EntitlementCreate:
+		data := EntitlementCreateEvent{}
+		err = json.Unmarshal(*event.DataInternal, &data)
+		event.Data = &data
+	case webhook_event_type.EntitlementUpdate:
+		data := EntitlementUpdateEvent{}
+		err = json.Unmarshal(*event.DataInternal, &data)
+		event.Data = &data
+	case webhook_event_type.EntitlementDelete:
+		data := EntitlementDeleteEvent{}
+		err = json.Unmarshal(*event.DataInternal, &data)
+		event.Data = &data
+	case webhook_event_type.QuestUserEnrollment:
+		event.Data = &QuestUserEnrollmentEvent{Raw: *event.DataInternal}
+	}
+
+	return err
+}
+
+// Time parses the event timestamp, accepting both RFC 3339 and Discord's timezone-less format (as UTC)
+func (event *WebhookEvent) Time() (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, event.Timestamp); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(webhookEventTimestampLayout, event.Timestamp)
+}
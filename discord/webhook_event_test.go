@@ -0,0 +1,81 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord/integration_type"
+	"github.com/JackHumphries9/dapper-go/discord/webhook_event_type"
+)
+
+func parseTestWebhookEvent(t *testing.T, eventType webhook_event_type.WebhookEventType, data string) *WebhookEvent {
+	t.Helper()
+
+	payload, err := ParseWebhookEvent(`{"version": 1, "application_id": "1", "type": 1, "event": {"type": "` +
+		string(eventType) + `", "timestamp": "2026-01-05T18:00:00.123456", "data": ` + data + `}}`)
+	if err != nil {
+		t.Fatalf("%s: %v", eventType, err)
+	}
+	if payload.IsPing() || payload.Event == nil || payload.Event.Type != eventType {
+		t.Fatalf("%s: unexpected payload %+v", eventType, payload)
+	}
+
+	return payload.Event
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	authorized := parseTestWebhookEvent(t, webhook_event_type.ApplicationAuthorized,
+		`{"integration_type": 1, "user": {"id": "2"}, "scopes": ["applications.commands"]}`)
+	if data, ok := authorized.Data.(*ApplicationAuthorizedEvent); !ok || data.User.Id != 2 ||
+		data.IntegrationType == nil || *data.IntegrationType != integration_type.UserInstall || len(data.Scopes) != 1 {
+		t.Errorf("Unexpected application authorized data %+v", authorized.Data)
+	}
+
+	deauthorized := parseTestWebhookEvent(t, webhook_event_type.ApplicationDeauthorized, `{"user": {"id": "2"}}`)
+	if data, ok := deauthorized.Data.(*ApplicationDeauthorizedEvent); !ok || data.User.Id != 2 {
+		t.Errorf("Unexpected application deauthorized data %+v", deauthorized.Data)
+	}
+
+	entitlement := `{"id": "3", "sku_id": "4", "application_id": "1", "user_id": "2", "type": 8, "deleted": false}`
+
+	created := parseTestWebhookEvent(t, webhook_event_type.EntitlementCreate, entitlement)
+	if data, ok := created.Data.(*EntitlementCreateEvent); !ok || data.ID != 3 || data.SkuID != 4 {
+		t.Errorf("Unexpected entitlement create data %+v", created.Data)
+	}
+
+	updated := parseTestWebhookEvent(t, webhook_event_type.EntitlementUpdate, entitlement)
+	if data, ok := updated.Data.(*EntitlementUpdateEvent); !ok || data.ID != 3 {
+		t.Errorf("Unexpected entitlement update data %+v", updated.Data)
+	}
+
+	deleted := parseTestWebhookEvent(t, webhook_event_type.EntitlementDelete, entitlement)
+	if data, ok := deleted.Data.(*EntitlementDeleteEvent); !ok || data.ID != 3 {
+		t.Errorf("Unexpected entitlement delete data %+v", deleted.Data)
+	}
+
+	quest := parseTestWebhookEvent(t, webhook_event_type.QuestUserEnrollment, `{"user_id": "2", "quest_id": "5"}`)
+	if data, ok := quest.Data.(*QuestUserEnrollmentEvent); !ok || string(data.Raw) != `{"user_id": "2", "quest_id": "5"}` {
+		t.Errorf("Unexpected quest user enrollment data %+v", quest.Data)
+	}
+
+	unknown := parseTestWebhookEvent(t, "SOMETHING_NEW", `{}`)
+	if unknown.Data != nil {
+		t.Errorf("Expected no data for an unknown event type, got %+v", unknown.Data)
+	}
+
+	timestamp, err := unknown.Time()
+	if err != nil || !timestamp.Equal(time.Date(2026, 1, 5, 18, 0, 0, 123456000, time.UTC)) {
+		t.Errorf("Unexpected event time %v (%v)", timestamp, err)
+	}
+}
+
+func TestParseWebhookPing(t *testing.T) {
+	payload, err := ParseWebhookEvent(`{"version": 1, "application_id": "1", "type": 0}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !payload.IsPing() || payload.Event != nil {
+		t.Errorf("Expected a ping without an event, got %+v", payload)
+	}
+}
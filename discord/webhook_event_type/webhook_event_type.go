@@ -0,0 +1,12 @@
+package webhook_event_type
+
+type WebhookEventType string
+
+const (
+	ApplicationAuthorized   WebhookEventType = "APPLICATION_AUTHORIZED"   // The app was added to a guild or user account
+	ApplicationDeauthorized WebhookEventType = "APPLICATION_DEAUTHORIZED" // The app was removed from a user account
+	EntitlementCreate       WebhookEventType = "ENTITLEMENT_CREATE"       // An entitlement was created
+	EntitlementUpdate       WebhookEventType = "ENTITLEMENT_UPDATE"       // An entitlement was updated
+	EntitlementDelete       WebhookEventType = "ENTITLEMENT_DELETE"       // An entitlement was deleted
+	QuestUserEnrollment     WebhookEventType = "QUEST_USER_ENROLLMENT"    // A user enrolled in a quest
+)
@@ -0,0 +1,8 @@
+package webhook_type
+
+type WebhookType int
+
+const (
+	Ping  WebhookType = iota // 0 - Sent by Discord to test the events endpoint
+	Event                    // 1 - An event, the body contains the event details
+)
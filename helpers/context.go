@@ -15,7 +15,11 @@ func RemoveContextIdFromString(customId string) string {
 }
 
 func GetContextFromId(customId string) *string {
-	sp := strings.Split(customId, ":")
+	return GetContextFromIdWithDelimiter(customId, ":")
+}
+
+func GetContextFromIdWithDelimiter(customId string, delimiter string) *string {
+	sp := strings.Split(customId, delimiter)
 
 	if len(sp) > 1 {
 		return &sp[len(sp)-1]
@@ -0,0 +1,20 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+)
+
+// ImageDataURI encodes an image as the data URI Discord expects for icons and avatars, supporting PNG, JPEG, GIF and WebP
+func ImageDataURI(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+
+	switch contentType {
+	case "image/png", "image/jpeg", "image/gif", "image/webp":
+	default:
+		return "", fmt.Errorf("unsupported image type %s", contentType)
+	}
+
+	return fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(data)), nil
+}
@@ -0,0 +1,61 @@
+package routers
+
+import (
+	"github.com/JackHumphries9/dapper-go/actions"
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/interaction_callback_type"
+	"github.com/JackHumphries9/dapper-go/discord/message_flags"
+	"github.com/JackHumphries9/dapper-go/helpers"
+)
+
+// guardAction runs the checks configured on the action's options before it is dispatched.
+// A non nil response means the action must not run and the response is sent instead.
+func guardAction(itc *actions.InteractionContext, actionId string, options actions.ActionOptions, owners []discord.Snowflake) *discord.InteractionResponse {
+	if denial := itc.CheckAccess(options, owners); denial != nil {
+		denied := options.Denied
+		if denied == nil {
+			denied = actions.DefaultDenial
+		}
+
+		return ephemeralResponse(denied(itc, *denial))
+	}
+
+	if sku := itc.MissingSKU(options.RequiredSKUs); sku != nil {
+		upsell := options.Upsell
+		if upsell == nil {
+			upsell = actions.DefaultUpsell
+		}
+
+		return ephemeralResponse(upsell(itc, *sku))
+	}
+
+	// Cooldowns are checked last so denied interactions don't use up a bucket
+	if retryAt := itc.CheckCooldowns(actionId, options); retryAt != nil {
+		onCooldown := options.OnCooldown
+		if onCooldown == nil {
+			onCooldown = actions.DefaultCooldownMessage
+		}
+
+		return ephemeralResponse(onCooldown(itc, *retryAt))
+	}
+
+	return nil
+}
+
+func ephemeralResponse(data discord.ResponseEditData) *discord.InteractionResponse {
+	flags := message_flags.Ephemeral
+	if data.Flags != nil {
+		flags.AddFlag(message_flags.MessageFlags(*data.Flags))
+	}
+
+	return &discord.InteractionResponse{
+		Type: interaction_callback_type.ChannelMessageWithSource,
+		Data: &discord.MessageCallbackData{
+			Content:         data.Content,
+			Embeds:          data.Embeds,
+			AllowedMentions: data.AllowedMentions,
+			Components:      data.Components,
+			Flags:           helpers.Ptr(int(flags)),
+		},
+	}
+}
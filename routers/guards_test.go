@@ -0,0 +1,85 @@
+package routers
+
+import (
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/actions"
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/entitlement_type"
+	"github.com/JackHumphries9/dapper-go/discord/message_flags"
+	"github.com/JackHumphries9/dapper-go/helpers"
+)
+
+const guardTestInteraction = `{
+	"id": "1",
+	"type": 3,
+	"token": "t",
+	"guild_id": "10",
+	"member": {"user": {"id": "5"}, "roles": [], "permissions": "0"},
+	"data": {"custom_id": "premium", "component_type": 2}
+}`
+
+func TestGuardActionEntitlements(t *testing.T) {
+	interaction, err := discord.ParseInteraction(guardTestInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	guildId := discord.Snowflake(10)
+	consumed := true
+	options := actions.ActionOptions{
+		RequiredSKUs: []actions.RequiredSKU{{SkuId: 1, Scope: actions.EntitlementScopeGuild}},
+	}
+
+	cases := []struct {
+		name         string
+		entitlements []discord.Entitlement
+		allowed      bool
+	}{
+		{"no entitlement", nil, false},
+		{"guild entitlement", []discord.Entitlement{{SkuID: 1, GuildID: &guildId, Type: entitlement_type.ApplicationSubscription}}, true},
+		{"test entitlement", []discord.Entitlement{{SkuID: 1, GuildID: &guildId, Type: entitlement_type.TestModeSubscription}}, true},
+		{"consumed entitlement", []discord.Entitlement{{SkuID: 1, GuildID: &guildId, Type: entitlement_type.Purchase, Consumed: &consumed}}, false},
+	}
+
+	for _, c := range cases {
+		interaction.Entitlements = c.entitlements
+		itc := actions.NewInteractionContext(interaction, nil, true)
+
+		response := guardAction(&itc, "premium", options, nil)
+		if c.allowed && response != nil {
+			t.Errorf("%s: expected the action to run, got %+v", c.name, response)
+		}
+		if !c.allowed && response == nil {
+			t.Errorf("%s: expected an upsell", c.name)
+		}
+	}
+}
+
+func TestGuardActionUpsell(t *testing.T) {
+	interaction, err := discord.ParseInteraction(guardTestInteraction)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	itc := actions.NewInteractionContext(interaction, nil, true)
+	options := actions.ActionOptions{
+		RequiredSKUs: []actions.RequiredSKU{{SkuId: 1, Scope: actions.EntitlementScopeUser}},
+		Upsell: func(itc *actions.InteractionContext, sku actions.RequiredSKU) discord.ResponseEditData {
+			return discord.ResponseEditData{Content: helpers.Ptr("Upgrade to use this")}
+		},
+	}
+
+	response := guardAction(&itc, "premium", options, nil)
+	if response == nil {
+		t.Fatal("Expected an upsell")
+	}
+
+	data := response.Data.(*discord.MessageCallbackData)
+	if data.Content == nil || *data.Content != "Upgrade to use this" {
+		t.Errorf("Expected the custom upsell, got %+v", data)
+	}
+	if data.Flags == nil || !message_flags.MessageFlags(*data.Flags).HasFlag(message_flags.Ephemeral) {
+		t.Errorf("Expected the upsell to be ephemeral")
+	}
+}
@@ -15,6 +15,7 @@ import (
 type InteractionRouter struct {
 	actions        map[string]actions.Action
 	stateDelimiter string
+	owners         []discord.Snowflake
 }
 
 func NewInteractionRouter(stateDelimiter string) InteractionRouter {
@@ -24,6 +25,11 @@ func NewInteractionRouter(stateDelimiter string) InteractionRouter {
 	}
 }
 
+// SetOwners sets the users allowed to run actions marked OwnerOnly
+func (ir *InteractionRouter) SetOwners(owners ...discord.Snowflake) {
+	ir.owners = owners
+}
+
 func (ir *InteractionRouter) RouteInteraction(interaction *discord.Interaction) (discord.InteractionResponse, error) {
 	var interactionCustomId string
 
@@ -47,11 +53,16 @@ func (ir *InteractionRouter) RouteInteraction(interaction *discord.Interaction)
 		deferralChan := make(chan *discord.InteractionResponse)
 
 		itc := actions.NewInteractionContext(interaction, deferralChan, action.Options().CancelDefer)
+		itc.SetStateDelimiter(ir.stateDelimiter)
 
 		if action.Options().Ephemeral {
 			itc.SetEphemeral(true)
 		}
 
+		if response := guardAction(&itc, action.CustomID(), action.Options(), ir.owners); response != nil {
+			return *response, nil
+		}
+
 		go action.Handler(&itc)
 
 		if !action.Options().CancelDefer {
@@ -93,7 +104,7 @@ func (ir *InteractionRouter) RegisterCommandsWithDiscord(appId discord.Snowflake
 
 	for _, cmd := range ir.actions {
 		if cmd.Type() == actions.ActionTypeCommand {
-			discordCommands = append(discordCommands, cmd.(actions.Command).Command)
+			discordCommands = append(discordCommands, cmd.(actions.Command).ApplicationCommand())
 		}
 	}
 
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/webhook_event_type"
+	"github.com/JackHumphries9/dapper-go/verification"
+)
+
+type EventListener func(payload *discord.WebhookEventPayload)
+
+type EventHandlerOptions struct {
+	PublicKey    ed25519.PublicKey
+	DapperLogger *DapperLogger
+}
+
+// EventHandler receives Discord's webhook events, register it as the app's Event Webhooks URL
+type EventHandler struct {
+	opts      EventHandlerOptions
+	logger    *DapperLogger
+	mu        sync.RWMutex
+	listeners map[webhook_event_type.WebhookEventType][]EventListener
+}
+
+func (eh *EventHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method is supported", http.StatusMethodNotAllowed)
+		eh.logger.Error("Only POST method is supported")
+		return
+	}
+
+	verify := verification.Verify(r, eh.opts.PublicKey)
+
+	if !verify {
+		eh.logger.Error("Recieved an invalid request")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	rawBody, err := io.ReadAll(r.Body)
+	defer r.Body.Close()
+
+	if err != nil {
+		eh.logger.Error("Failed to read body")
+		return
+	}
+
+	payload, err := discord.ParseWebhookEvent(string(rawBody))
+
+	if err != nil {
+		eh.logger.Error(fmt.Sprintf("Failed to parse webhook event: %v\n", err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Discord expects every event, including pings, to be acknowledged with an empty 204
+	w.WriteHeader(http.StatusNoContent)
+
+	if payload.IsPing() || payload.Event == nil {
+		return
+	}
+
+	eh.mu.RLock()
+	listeners := eh.listeners[payload.Event.Type]
+	eh.mu.RUnlock()
+
+	for _, listener := range listeners {
+		go listener(payload)
+	}
+}
+
+// On registers a listener for an event type, listeners run in their own goroutine after the event is acknowledged
+func (eh *EventHandler) On(eventType webhook_event_type.WebhookEventType, listener EventListener) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	eh.listeners[eventType] = append(eh.listeners[eventType], listener)
+}
+
+func (eh *EventHandler) OnApplicationAuthorized(listener func(payload *discord.WebhookEventPayload, event *discord.ApplicationAuthorizedEvent)) {
+	onTyped(eh, webhook_event_type.ApplicationAuthorized, listener)
+}
+
+func (eh *EventHandler) OnApplicationDeauthorized(listener func(payload *discord.WebhookEventPayload, event *discord.ApplicationDeauthorizedEvent)) {
+	onTyped(eh, webhook_event_type.ApplicationDeauthorized, listener)
+}
+
+func (eh *EventHandler) OnEntitlementCreate(listener func(payload *discord.WebhookEventPayload, event *discord.EntitlementCreateEvent)) {
+	onTyped(eh, webhook_event_type.EntitlementCreate, listener)
+}
+
+func (eh *EventHandler) OnEntitlementUpdate(listener func(payload *discord.WebhookEventPayload, event *discord.EntitlementUpdateEvent)) {
+	onTyped(eh, webhook_event_type.EntitlementUpdate, listener)
+}
+
+func (eh *EventHandler) OnEntitlementDelete(listener func(payload *discord.WebhookEventPayload, event *discord.EntitlementDeleteEvent)) {
+	onTyped(eh, webhook_event_type.EntitlementDelete, listener)
+}
+
+func (eh *EventHandler) OnQuestUserEnrollment(listener func(payload *discord.WebhookEventPayload, event *discord.QuestUserEnrollmentEvent)) {
+	onTyped(eh, webhook_event_type.QuestUserEnrollment, listener)
+}
+
+func onTyped[E any](eh *EventHandler, eventType webhook_event_type.WebhookEventType, listener func(payload *discord.WebhookEventPayload, event *E)) {
+	eh.On(eventType, func(payload *discord.WebhookEventPayload) {
+		event, ok := payload.Event.Data.(*E)
+		if !ok {
+			eh.logger.Error(fmt.Sprintf("Webhook event %s had unexpected data %T\n", eventType, payload.Event.Data))
+			return
+		}
+
+		listener(payload, event)
+	})
+}
+
+func NewEventHandler(publicKey string) *EventHandler {
+	key, err := hex.DecodeString(publicKey)
+
+	if err != nil {
+		panic("Invalid public key")
+	}
+
+	return NewEventHandlerWithOptions(EventHandlerOptions{
+		PublicKey:    ed25519.PublicKey(key),
+		DapperLogger: &DefaultLogger,
+	})
+}
+
+func NewEventHandlerWithOptions(opts EventHandlerOptions) *EventHandler {
+	if opts.DapperLogger == nil {
+		opts.DapperLogger = &DefaultLogger
+	}
+
+	return &EventHandler{
+		opts:      opts,
+		logger:    opts.DapperLogger,
+		listeners: make(map[webhook_event_type.WebhookEventType][]EventListener),
+	}
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/webhook_event_type"
+)
+
+func newTestEventHandler(t *testing.T) (*EventHandler, ed25519.PrivateKey) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewEventHandler(hex.EncodeToString(publicKey)), privateKey
+}
+
+func signedEventRequest(privateKey ed25519.PrivateKey, body string) *http.Request {
+	timestamp := "1700000000"
+	request := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+	request.Header.Set("X-Signature-Timestamp", timestamp)
+	request.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(privateKey, []byte(timestamp+body))))
+	return request
+}
+
+func webhookEventBody(eventType webhook_event_type.WebhookEventType, data string) string {
+	return `{"version": 1, "application_id": "1", "type": 1, "event": {"type": "` + string(eventType) +
+		`", "timestamp": "2026-01-05T18:00:00", "data": ` + data + `}}`
+}
+
+func waitForEvent[E any](t *testing.T, events chan E) E {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the listener")
+	}
+
+	var empty E
+	return empty
+}
+
+func TestEventHandlerDispatch(t *testing.T) {
+	handler, privateKey := newTestEventHandler(t)
+
+	entitlements := make(chan *discord.EntitlementCreateEvent, 1)
+	handler.OnEntitlementCreate(func(payload *discord.WebhookEventPayload, event *discord.EntitlementCreateEvent) {
+		entitlements <- event
+	})
+
+	quests := make(chan *discord.QuestUserEnrollmentEvent, 1)
+	handler.OnQuestUserEnrollment(func(payload *discord.WebhookEventPayload, event *discord.QuestUserEnrollmentEvent) {
+		quests <- event
+	})
+
+	authorized := make(chan *discord.ApplicationAuthorizedEvent, 1)
+	handler.OnApplicationAuthorized(func(payload *discord.WebhookEventPayload, event *discord.ApplicationAuthorizedEvent) {
+		authorized <- event
+	})
+
+	recorder := httptest.NewRecorder()
+	handler.Handle(recorder, signedEventRequest(privateKey, webhookEventBody(webhook_event_type.EntitlementCreate,
+		`{"id": "3", "sku_id": "4", "application_id": "1", "type": 8}`)))
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d", recorder.Code)
+	}
+	if event := waitForEvent(t, entitlements); event.SkuID != 4 {
+		t.Errorf("Unexpected entitlement %+v", event)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.Handle(recorder, signedEventRequest(privateKey, webhookEventBody(webhook_event_type.QuestUserEnrollment, `{"quest_id": "5"}`)))
+	if event := waitForEvent(t, quests); string(event.Raw) != `{"quest_id": "5"}` {
+		t.Errorf("Unexpected quest enrollment %s", event.Raw)
+	}
+
+	if len(authorized) != 0 {
+		t.Errorf("Expected listeners for other event types not to run")
+	}
+}
+
+func TestEventHandlerRejectsInvalidRequests(t *testing.T) {
+	handler, privateKey := newTestEventHandler(t)
+
+	called := make(chan struct{}, 1)
+	handler.On(webhook_event_type.EntitlementCreate, func(payload *discord.WebhookEventPayload) {
+		called <- struct{}{}
+	})
+
+	body := webhookEventBody(webhook_event_type.EntitlementCreate, `{"id": "3"}`)
+
+	request := signedEventRequest(privateKey, body)
+	request.Header.Set("X-Signature-Timestamp", "1700000001")
+	recorder := httptest.NewRecorder()
+	handler.Handle(recorder, request)
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected a bad signature to be rejected with 401, got %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.Handle(recorder, httptest.NewRequest(http.MethodGet, "/events", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected GET to be rejected with 405, got %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.Handle(recorder, signedEventRequest(privateKey, `{"version": 1, "application_id": "1", "type": 0}`))
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("Expected pings to be acknowledged with 204, got %d", recorder.Code)
+	}
+
+	if len(called) != 0 {
+		t.Errorf("Expected no listeners to run")
+	}
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/JackHumphries9/dapper-go/client"
+	"github.com/JackHumphries9/dapper-go/discord"
+	"github.com/JackHumphries9/dapper-go/discord/oauth_scopes"
+)
+
+const linkedRolesStateCookie = "dapper_linked_roles_state"
+
+// LinkedRolesMetadataFunc computes the role connection to push for a user that completed the OAuth flow
+type LinkedRolesMetadataFunc func(r *http.Request, user *discord.User, authedUser *client.AuthorizedUser) (discord.ApplicationRoleConnection, error)
+
+type LinkedRolesHandlerOptions struct {
+	// The OAuth client's redirect uri must point at the handler's Callback
+	OAuthClient  *client.OAuthClient
+	GetMetadata  LinkedRolesMetadataFunc
+	DapperLogger *DapperLogger
+	// Where the user is sent once their role connection is updated, a plain text page is shown when empty
+	SuccessRedirectUrl string
+	// Defaults to identify and role_connections.write
+	Scopes []oauth_scopes.OAuthScope
+}
+
+// LinkedRolesHandler runs the verification flow for linked roles, serve Verify at the application's
+// role connections verification url and Callback at the OAuth client's redirect uri
+type LinkedRolesHandler struct {
+	opts   LinkedRolesHandlerOptions
+	logger *DapperLogger
+}
+
+// Verify starts the OAuth flow, storing the state in a cookie so the callback can check it
+func (lh *LinkedRolesHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	stateBytes := make([]byte, 16)
+	if _, err := rand.Read(stateBytes); err != nil {
+		lh.logger.Error(fmt.Sprintf("Failed to generate OAuth state: %v\n", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	state := hex.EncodeToString(stateBytes)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     linkedRolesStateCookie,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   300,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, lh.opts.OAuthClient.BuildAuthorizationURL(lh.opts.Scopes, state), http.StatusFound)
+}
+
+// Callback exchanges the code, computes the user's metadata and pushes it to Discord
+func (lh *LinkedRolesHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	stateCookie, err := r.Cookie(linkedRolesStateCookie)
+	if err != nil || stateCookie.Value == "" || stateCookie.Value != r.URL.Query().Get("state") {
+		lh.logger.Error("Linked roles callback had an invalid state")
+		http.Error(w, "Invalid state", http.StatusForbidden)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:   linkedRolesStateCookie,
+		Path:   "/",
+		MaxAge: -1,
+	})
+
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		http.Error(w, "Missing code", http.StatusBadRequest)
+		return
+	}
+
+	authedUser, err := lh.opts.OAuthClient.AuthorizeUserFromCode(code)
+	if err != nil {
+		lh.logger.Error(fmt.Sprintf("Failed to authorize linked roles user: %v\n", err))
+		http.Error(w, "Failed to authorize with Discord", http.StatusInternalServerError)
+		return
+	}
+
+	user, err := authedUser.FetchUser()
+	if err != nil {
+		lh.logger.Error(fmt.Sprintf("Failed to fetch linked roles user: %v\n", err))
+		http.Error(w, "Failed to fetch user", http.StatusInternalServerError)
+		return
+	}
+
+	roleConnection, err := lh.opts.GetMetadata(r, user, authedUser)
+	if err != nil {
+		lh.logger.Error(fmt.Sprintf("Failed to compute role connection metadata: %v\n", err))
+		http.Error(w, "Failed to compute role connection", http.StatusInternalServerError)
+		return
+	}
+
+	_, err = authedUser.UpdateRoleConnection(roleConnection)
+	if err != nil {
+		lh.logger.Error(fmt.Sprintf("Failed to update role connection: %v\n", err))
+		http.Error(w, "Failed to update role connection", http.StatusInternalServerError)
+		return
+	}
+
+	if lh.opts.SuccessRedirectUrl != "" {
+		http.Redirect(w, r, lh.opts.SuccessRedirectUrl, http.StatusFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Your roles have been linked, you can now return to Discord."))
+}
+
+func NewLinkedRolesHandler(oauthClient *client.OAuthClient, getMetadata LinkedRolesMetadataFunc) *LinkedRolesHandler {
+	return NewLinkedRolesHandlerWithOptions(LinkedRolesHandlerOptions{
+		OAuthClient: oauthClient,
+		GetMetadata: getMetadata,
+	})
+}
+
+func NewLinkedRolesHandlerWithOptions(opts LinkedRolesHandlerOptions) *LinkedRolesHandler {
+	if opts.DapperLogger == nil {
+		opts.DapperLogger = &DefaultLogger
+	}
+
+	if len(opts.Scopes) == 0 {
+		opts.Scopes = []oauth_scopes.OAuthScope{oauth_scopes.IDENFITY, oauth_scopes.ROLE_CONNECTIONS_WRITE}
+	}
+
+	return &LinkedRolesHandler{
+		opts:   opts,
+		logger: opts.DapperLogger,
+	}
+}
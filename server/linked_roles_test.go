@@ -0,0 +1,140 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/JackHumphries9/dapper-go/client"
+	"github.com/JackHumphries9/dapper-go/discord"
+)
+
+type roundTripFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// newTestOAuthClient answers the token, user and role connection requests of the linked roles flow
+func newTestOAuthClient(t *testing.T, pushed *string) *client.OAuthClient {
+	oauthClient := client.NewOAuthClient("123", "secret", "https://example.com/callback")
+	oauthClient.Client = &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		body := ""
+		switch {
+		case request.Method == "POST" && strings.HasSuffix(request.URL.Path, "/oauth2/token"):
+			body = `{"access_token": "access", "refresh_token": "refresh", "token_type": "Bearer", "expires_in": 3600}`
+		case request.Method == "GET" && strings.HasSuffix(request.URL.Path, "/users/@me"):
+			body = `{"id": "5", "username": "cat"}`
+		case request.Method == "PUT" && strings.HasSuffix(request.URL.Path, "/users/@me/applications/123/role-connection"):
+			if request.Header.Get("Authorization") != "Bearer access" {
+				t.Errorf("Expected the user's access token, got %q", request.Header.Get("Authorization"))
+			}
+			sent, _ := io.ReadAll(request.Body)
+			*pushed = string(sent)
+			body = string(sent)
+		default:
+			t.Errorf("Unexpected request %s %s", request.Method, request.URL)
+		}
+
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Request: request}, nil
+	})}
+
+	return oauthClient
+}
+
+func TestLinkedRolesVerify(t *testing.T) {
+	handler := NewLinkedRolesHandler(client.NewOAuthClient("123", "secret", "https://example.com/callback"), nil)
+
+	recorder := httptest.NewRecorder()
+	handler.Verify(recorder, httptest.NewRequest(http.MethodGet, "/verify", nil))
+
+	if recorder.Code != http.StatusFound {
+		t.Fatalf("Expected a redirect, got %d", recorder.Code)
+	}
+
+	cookies := recorder.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != linkedRolesStateCookie || cookies[0].Value == "" || !cookies[0].HttpOnly {
+		t.Fatalf("Expected an http only state cookie, got %+v", cookies)
+	}
+
+	location, err := url.Parse(recorder.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location.Query().Get("state") != cookies[0].Value {
+		t.Errorf("Expected the redirect state to match the cookie, got %q and %q", location.Query().Get("state"), cookies[0].Value)
+	}
+	if location.Query().Get("scope") != "identify role_connections.write" {
+		t.Errorf("Expected the default scopes, got %q", location.Query().Get("scope"))
+	}
+}
+
+func TestLinkedRolesCallbackState(t *testing.T) {
+	handler := NewLinkedRolesHandler(client.NewOAuthClient("123", "secret", "https://example.com/callback"), nil)
+
+	cases := []struct {
+		name   string
+		cookie string
+		state  string
+	}{
+		{"missing cookie", "", "abc"},
+		{"mismatched state", "abc", "def"},
+		{"missing state", "abc", ""},
+	}
+
+	for _, c := range cases {
+		request := httptest.NewRequest(http.MethodGet, "/callback?code=code&state="+c.state, nil)
+		if c.cookie != "" {
+			request.AddCookie(&http.Cookie{Name: linkedRolesStateCookie, Value: c.cookie})
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.Callback(recorder, request)
+
+		if recorder.Code != http.StatusForbidden {
+			t.Errorf("%s: expected 403, got %d", c.name, recorder.Code)
+		}
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/callback?state=abc", nil)
+	request.AddCookie(&http.Cookie{Name: linkedRolesStateCookie, Value: "abc"})
+	recorder := httptest.NewRecorder()
+	handler.Callback(recorder, request)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected a missing code to be rejected with 400, got %d", recorder.Code)
+	}
+}
+
+func TestLinkedRolesCallback(t *testing.T) {
+	var pushed string
+	handler := NewLinkedRolesHandlerWithOptions(LinkedRolesHandlerOptions{
+		OAuthClient: newTestOAuthClient(t, &pushed),
+		GetMetadata: func(r *http.Request, user *discord.User, authedUser *client.AuthorizedUser) (discord.ApplicationRoleConnection, error) {
+			return discord.ApplicationRoleConnection{
+				Metadata: map[string]string{"verified": discord.RoleConnectionBoolean(user.Id == 5)},
+			}, nil
+		},
+		SuccessRedirectUrl: "https://example.com/done",
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/callback?code=code&state=abc", nil)
+	request.AddCookie(&http.Cookie{Name: linkedRolesStateCookie, Value: "abc"})
+	recorder := httptest.NewRecorder()
+	handler.Callback(recorder, request)
+
+	if recorder.Code != http.StatusFound || recorder.Header().Get("Location") != "https://example.com/done" {
+		t.Errorf("Expected a redirect to the success url, got %d %q", recorder.Code, recorder.Header().Get("Location"))
+	}
+	if pushed != `{"metadata":{"verified":"1"}}` {
+		t.Errorf("Unexpected role connection %s", pushed)
+	}
+
+	// The state can only be used once
+	cookies := recorder.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != linkedRolesStateCookie || cookies[0].MaxAge >= 0 {
+		t.Errorf("Expected the state cookie to be cleared, got %+v", cookies)
+	}
+}